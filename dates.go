@@ -0,0 +1,699 @@
+package agstring
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// DateOptions configures ExtractDates.
+type DateOptions struct {
+	// Reference is the time relative expressions such as "yesterday" are resolved against,
+	// which also completes dates written without a year. Dates are returned in its location.
+	// Defaults to the current time.
+	Reference time.Time
+	// DayFirst reads ambiguous numeric dates such as "03/04/2020" day first, as in most of the
+	// world, rather than month first as in the US.
+	DayFirst bool
+	// Languages holds the languages month names and relative expressions are read in, among
+	// en, de, fr, es and tr. Defaults to English.
+	Languages []string
+}
+
+// DateMatch is a date or a date range found in a string.
+type DateMatch struct {
+	// Start and End are the byte offsets of the date in the searched string.
+	Start, End int
+	// Time is the date, or the first day of a range. It is midnight unless a time of day is
+	// given.
+	Time time.Time
+	// Until is the last day of a range, such as "21-23 March 2018" or "March 2018", and zero
+	// for single dates.
+	Until time.Time
+	// Confidence tells how likely the match is meant as a date, from 0 to 1. Complete dates
+	// score higher than dates without a year or ambiguous numeric dates.
+	Confidence float64
+}
+
+// dateCandidate is a match along with whether its year was written.
+type dateCandidate struct {
+	DateMatch
+	hasYear bool
+}
+
+type dateUnit struct{ years, months, days int }
+
+// dateLanguage holds the date words of a language, lower cased without diacritics.
+type dateLanguage struct {
+	lang     string
+	months   [12][]string
+	weekdays []string
+	// days maps words such as "yesterday" to their offsets from today.
+	days  map[string]int
+	units map[string]dateUnit
+	// past and future hold the words preceding or following relative expressions, such as
+	// "ago" in "3 days ago".
+	pastBefore, pastAfter, futureBefore, futureAfter []string
+	// ones holds articles counting one, such as "a" in "a week ago".
+	ones []string
+	// openers and connectors join dates into ranges, such as "from" and "to".
+	openers, connectors []string
+}
+
+var (
+	dayUnit   = dateUnit{days: 1}
+	weekUnit  = dateUnit{days: 7}
+	monthUnit = dateUnit{months: 1}
+	yearUnit  = dateUnit{years: 1}
+)
+
+var dateLanguages = []*dateLanguage{
+	{
+		lang: "en",
+		months: [12][]string{
+			{"january", "jan"}, {"february", "feb"}, {"march", "mar"}, {"april", "apr"}, {"may"},
+			{"june", "jun"}, {"july", "jul"}, {"august", "aug"}, {"september", "sept", "sep"},
+			{"october", "oct"}, {"november", "nov"}, {"december", "dec"},
+		},
+		weekdays: []string{
+			"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday", "mon",
+			"tue", "tues", "wed", "thu", "thur", "thurs", "fri", "sat", "sun",
+		},
+		days: map[string]int{
+			"today": 0, "yesterday": -1, "tomorrow": 1, "the day before yesterday": -2,
+			"day before yesterday": -2, "the day after tomorrow": 2, "day after tomorrow": 2,
+		},
+		units: map[string]dateUnit{
+			"day": dayUnit, "days": dayUnit, "week": weekUnit, "weeks": weekUnit,
+			"month": monthUnit, "months": monthUnit, "year": yearUnit, "years": yearUnit,
+		},
+		pastAfter:    []string{"ago"},
+		futureBefore: []string{"in"},
+		ones:         []string{"a", "an"},
+		openers:      []string{"from", "between"},
+		connectors:   []string{"to", "until", "till", "through", "and"},
+	},
+	{
+		lang: "de",
+		months: [12][]string{
+			{"januar", "janner", "jan"}, {"februar", "feb"}, {"marz", "maerz", "mar"},
+			{"april", "apr"}, {"mai"}, {"juni", "jun"}, {"juli", "jul"}, {"august", "aug"},
+			{"september", "sept", "sep"}, {"oktober", "okt"}, {"november", "nov"},
+			{"dezember", "dez"},
+		},
+		weekdays: []string{
+			"montag", "dienstag", "mittwoch", "donnerstag", "freitag", "samstag", "sonnabend",
+			"sonntag",
+		},
+		days: map[string]int{
+			"heute": 0, "gestern": -1, "morgen": 1, "vorgestern": -2, "ubermorgen": 2,
+			"uebermorgen": 2,
+		},
+		units: map[string]dateUnit{
+			"tag": dayUnit, "tage": dayUnit, "tagen": dayUnit, "woche": weekUnit,
+			"wochen": weekUnit, "monat": monthUnit, "monate": monthUnit, "monaten": monthUnit,
+			"jahr": yearUnit, "jahre": yearUnit, "jahren": yearUnit,
+		},
+		pastBefore:   []string{"vor"},
+		futureBefore: []string{"in"},
+		ones:         []string{"einem", "einer", "einen"},
+		openers:      []string{"vom", "von", "zwischen"},
+		connectors:   []string{"bis", "und"},
+	},
+	{
+		lang: "fr",
+		months: [12][]string{
+			{"janvier", "janv"}, {"fevrier", "fevr", "fev"}, {"mars"}, {"avril", "avr"}, {"mai"},
+			{"juin"}, {"juillet", "juil"}, {"aout"}, {"septembre", "sept"}, {"octobre", "oct"},
+			{"novembre", "nov"}, {"decembre", "dec"},
+		},
+		weekdays: []string{"lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi", "dimanche"},
+		days: map[string]int{
+			"aujourd'hui": 0, "aujourdhui": 0, "hier": -1, "demain": 1, "avant-hier": -2,
+			"apres-demain": 2,
+		},
+		units: map[string]dateUnit{
+			"jour": dayUnit, "jours": dayUnit, "semaine": weekUnit, "semaines": weekUnit,
+			"mois": monthUnit, "an": yearUnit, "ans": yearUnit, "annee": yearUnit,
+			"annees": yearUnit,
+		},
+		pastBefore:   []string{"il y a"},
+		futureBefore: []string{"dans"},
+		openers:      []string{"du", "entre"},
+		connectors:   []string{"au", "et"},
+	},
+	{
+		lang: "es",
+		months: [12][]string{
+			{"enero", "ene"}, {"febrero", "feb"}, {"marzo", "mar"}, {"abril", "abr"}, {"mayo"},
+			{"junio", "jun"}, {"julio", "jul"}, {"agosto", "ago"},
+			{"septiembre", "setiembre", "sept", "sep"}, {"octubre", "oct"}, {"noviembre", "nov"},
+			{"diciembre", "dic"},
+		},
+		weekdays: []string{"lunes", "martes", "miercoles", "jueves", "viernes", "sabado", "domingo"},
+		days: map[string]int{
+			"hoy": 0, "ayer": -1, "manana": 1, "anteayer": -2, "antier": -2, "pasado manana": 2,
+		},
+		units: map[string]dateUnit{
+			"dia": dayUnit, "dias": dayUnit, "semana": weekUnit, "semanas": weekUnit,
+			"mes": monthUnit, "meses": monthUnit, "ano": yearUnit, "anos": yearUnit,
+		},
+		pastBefore:   []string{"hace"},
+		futureBefore: []string{"dentro de", "en"},
+		openers:      []string{"del", "desde", "entre"},
+		connectors:   []string{"al", "hasta", "y"},
+	},
+	{
+		lang: "tr",
+		months: [12][]string{
+			{"ocak"}, {"subat"}, {"mart"}, {"nisan"}, {"mayis"}, {"haziran"}, {"temmuz"},
+			{"agustos"}, {"eylul"}, {"ekim"}, {"kasim"}, {"aralik"},
+		},
+		weekdays: []string{"pazartesi", "sali", "carsamba", "persembe", "cuma", "cumartesi", "pazar"},
+		days:     map[string]int{"bugun": 0, "dun": -1, "yarin": 1, "obur gun": 2},
+		units: map[string]dateUnit{
+			"gun": dayUnit, "hafta": weekUnit, "ay": monthUnit, "yil": yearUnit, "sene": yearUnit,
+		},
+		pastAfter:   []string{"once"},
+		futureAfter: []string{"sonra"},
+		connectors:  []string{"ile", "ila"},
+	},
+}
+
+// datePairs holds the connectors which need an opener, such as "and" after "between".
+var datePairs = map[string]bool{"and": true, "und": true, "et": true, "y": true}
+
+var (
+	isoDateRegexp = regexp.MustCompile(
+		`\b(\d{4})([-/.])(\d{1,2})([-/.])(\d{1,2})(?:(?:t|\s+)(\d{1,2}):(\d{2})(?::(\d{2}))?)?\b`)
+	numericDateRegexp = regexp.MustCompile(`\b(\d{1,2})([-/.])(\d{1,2})([-/.])(\d{4}|\d{2})\b`)
+)
+
+// datePatterns holds the date words and patterns of a set of languages.
+type datePatterns struct {
+	languages []*dateLanguage
+	months    map[string]time.Month
+	// days maps words such as "yesterday" to their offsets from today.
+	days                map[string]int
+	openers, connectors map[string]bool
+	// dayMonth, monthDay and monthYear match dates with month names, relativeDay the words of
+	// days.
+	dayMonth, monthDay, monthYear, relativeDay *regexp.Regexp
+	// relative holds the relative expressions of each language, such as "3 days ago".
+	relative []relativeDatePattern
+}
+
+type relativeDatePattern struct {
+	lang *dateLanguage
+	re   *regexp.Regexp
+}
+
+var (
+	datePatternsMu sync.Mutex
+	datePatternsOf = make(map[string]*datePatterns)
+)
+
+// datePatternsFor returns the patterns of given languages, English by default, compiling them
+// on first use. Unsupported languages are skipped.
+func datePatternsFor(langs []string) *datePatterns {
+	if len(langs) == 0 {
+		langs = []string{"en"}
+	}
+	key := strings.Join(langs, ",")
+	datePatternsMu.Lock()
+	defer datePatternsMu.Unlock()
+	if p, ok := datePatternsOf[key]; ok {
+		return p
+	}
+	p := &datePatterns{
+		months:     make(map[string]time.Month),
+		days:       make(map[string]int),
+		openers:    make(map[string]bool),
+		connectors: map[string]bool{"-": true, "/": true},
+	}
+	for _, lang := range langs {
+		if l, ok := dateLanguageFor(lang); ok && !containsDateLanguage(p.languages, l) {
+			p.languages = append(p.languages, l)
+		}
+	}
+	p.compile()
+	datePatternsOf[key] = p
+	return p
+}
+
+// dateLanguageFor returns the date words of the base language of given tag.
+func dateLanguageFor(lang string) (*dateLanguage, bool) {
+	base, conf := language.Make(lang).Base()
+	for _, l := range dateLanguages {
+		if l.lang == lang || conf == language.Exact && l.lang == base.String() {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+func containsDateLanguage(ls []*dateLanguage, l *dateLanguage) bool {
+	for _, x := range ls {
+		if x == l {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *datePatterns) compile() {
+	var months, weekdays, days []string
+	for _, l := range p.languages {
+		for i, names := range l.months {
+			for _, name := range names {
+				p.months[name] = time.Month(i + 1)
+				months = append(months, name)
+			}
+		}
+		weekdays = append(weekdays, l.weekdays...)
+		for word, offset := range l.days {
+			p.days[word] = offset
+			days = append(days, word)
+		}
+		for _, opener := range l.openers {
+			p.openers[opener] = true
+		}
+		for _, connector := range l.connectors {
+			p.connectors[connector] = true
+		}
+		for _, re := range l.relativeRegexps() {
+			p.relative = append(p.relative, relativeDatePattern{lang: l, re: re})
+		}
+	}
+	if len(p.languages) == 0 {
+		return
+	}
+	month := "(" + regexpAlternation(months) + `)\b\.?`
+	weekday := `(?:(?:` + regexpAlternation(weekdays) + `)\b\.?,?\s+)?`
+	day := `(\d{1,2})(?:\.?(?:st|nd|rd|th|er|eme|e|o|a)\b)?\.?`
+	year := `(?:(?:,?\s+(?:de\s+)?|[/-]|,)(\d{4})\b)?`
+
+	p.dayMonth = regexp.MustCompile(`\b` + weekday + day +
+		`(?:\s*(?:-|to|bis|au|al|a)\s*` + day + `)?(?:\s+(?:of|de)\s+|[\s/-]+|,\s*)` + month + year)
+	p.monthDay = regexp.MustCompile(`\b` + weekday + month + `\s+(\d{1,2})(?:st|nd|rd|th)?\b` +
+		`(?:\s*(?:-|to|through)\s*(\d{1,2})(?:st|nd|rd|th)?\b)?(?:,?\s+(\d{4})\b)?`)
+	p.monthYear = regexp.MustCompile(`\b` + month + `,?\s+(\d{4})\b`)
+	p.relativeDay = regexp.MustCompile(`\b(` + regexpAlternation(days) + `)\b`)
+}
+
+// regexpAlternation returns a regular expression matching any of given words, longest first.
+func regexpAlternation(words []string) string {
+	words = append([]string(nil), words...)
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = strings.Replace(regexp.QuoteMeta(w), " ", `\s+`, -1)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// relativeRegexps returns the regular expressions of the relative expressions of the language,
+// with the expression words before or after the number, such as "in 3 days" or "3 days ago".
+func (l *dateLanguage) relativeRegexps() []*regexp.Regexp {
+	var units []string
+	for unit := range l.units {
+		units = append(units, unit)
+	}
+	number := `(\d+|[a-z]+(?:[ -][a-z]+){0,3}?)`
+	unit := `\s+(` + regexpAlternation(units) + `)\b`
+	var res []*regexp.Regexp
+	if words := append(append([]string(nil), l.pastBefore...), l.futureBefore...); len(words) > 0 {
+		res = append(res, regexp.MustCompile(`\b(`+regexpAlternation(words)+`)\s+`+number+unit))
+	}
+	if words := append(append([]string(nil), l.pastAfter...), l.futureAfter...); len(words) > 0 {
+		res = append(res, regexp.MustCompile(`\b`+number+unit+`\s+(`+regexpAlternation(words)+`)\b`))
+	}
+	return res
+}
+
+// ExtractDates returns the dates and date ranges found in given string, in order. It finds
+// ISO dates with an optional time of day ("2018-03-21 14:30"), numeric dates ("21.03.2018",
+// "3/21/18"), dates with month names in English, German, French, Spanish and Turkish ("Mon
+// 21st of March 2018", "21. März", "March 21-23, 2018", "21 de marzo de 2018", "Mart 2018"),
+// relative expressions ("yesterday", "3 days ago", "vor drei Tagen", "2 hafta sonra") and
+// ranges of them ("from 21 to 23 March", "1/3/2018 - 5/3/2018"). Words are only read in the
+// languages of the options, English by default, and single relative words such as "tomorrow"
+// score lower unless they are the whole string.
+func ExtractDates(s string, opts DateOptions) []DateMatch {
+	if opts.Reference.IsZero() {
+		opts.Reference = time.Now()
+	}
+	text, offsets := mapRunes(s, func(r rune) string { return strings.ToLower(unidecodeRune(r)) })
+	p := datePatternsFor(opts.Languages)
+	e := dateExtractor{text: text, source: s, offsets: offsets, opts: opts, patterns: p}
+	e.find(isoDateRegexp, e.iso)
+	e.find(numericDateRegexp, e.numeric)
+	if len(p.languages) > 0 {
+		e.find(p.dayMonth, e.dayMonth)
+		e.find(p.monthDay, e.monthDay)
+		e.find(p.monthYear, e.monthYear)
+		e.find(p.relativeDay, e.relativeDay)
+		for _, r := range p.relative {
+			e.find(r.re, e.relative(r.lang))
+		}
+	}
+	candidates := e.ranges(e.selected())
+	matches := make([]DateMatch, len(candidates))
+	for i, c := range candidates {
+		matches[i] = c.DateMatch
+		matches[i].Start, matches[i].End = offsets.ByteRange(c.Start, c.End)
+	}
+	return matches
+}
+
+// ParseDate returns the date given string consists of. See ExtractDates for the supported
+// formats. An error is returned if the string holds anything else, or a range.
+func ParseDate(s string, opts DateOptions) (time.Time, error) {
+	trimmed := strings.TrimSpace(s)
+	matches := ExtractDates(trimmed, opts)
+	if len(matches) != 1 || matches[0].Start != 0 || matches[0].End != len(trimmed) ||
+		!matches[0].Until.IsZero() {
+		return time.Time{}, errors.Errorf("invalid date %q", s)
+	}
+	return matches[0].Time, nil
+}
+
+type dateExtractor struct {
+	// text is the folded source string, with offsets mapping it back to the source.
+	text       string
+	source     string
+	offsets    *OffsetMap
+	opts       DateOptions
+	patterns   *datePatterns
+	candidates []dateCandidate
+	// loc holds the submatch offsets in text of the match a candidate is built from.
+	loc []int
+}
+
+// find adds the candidates built by fn from the submatches of re.
+func (e *dateExtractor) find(re *regexp.Regexp, fn func(m []string) (dateCandidate, bool)) {
+	for _, loc := range re.FindAllStringSubmatchIndex(e.text, -1) {
+		m := make([]string, len(loc)/2)
+		for i := range m {
+			if loc[2*i] >= 0 {
+				m[i] = e.text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		e.loc = loc
+		if c, ok := fn(m); ok {
+			// fn may skip the beginning or the end of the match, giving offsets in it
+			c.Start += loc[0]
+			if c.End > 0 {
+				c.End += loc[0]
+			} else {
+				c.End = loc[1]
+			}
+			e.candidates = append(e.candidates, c)
+		}
+	}
+}
+
+// selected returns the candidates not overlapping longer ones starting before them.
+func (e *dateExtractor) selected() []dateCandidate {
+	candidates := e.candidates
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Start != candidates[j].Start {
+			return candidates[i].Start < candidates[j].Start
+		}
+		return candidates[i].End > candidates[j].End
+	})
+	selected := candidates[:0]
+	end := 0
+	for _, c := range candidates {
+		if c.Start >= end {
+			selected = append(selected, c)
+			end = c.End
+		}
+	}
+	return selected
+}
+
+// date returns the date of given day, or false if it doesn't exist.
+func (e *dateExtractor) date(year int, month time.Month, day int) (time.Time, bool) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, e.opts.Reference.Location())
+	return t, month >= time.January && month <= time.December && t.Day() == day
+}
+
+// year completes years of two digits within a century around the reference time.
+func (e *dateExtractor) year(s string) int {
+	year, _ := strconv.Atoi(s)
+	if len(s) == 2 {
+		ref := e.opts.Reference.Year()
+		year += ref / 100 * 100
+		if year > ref+20 {
+			year -= 100
+		}
+	}
+	return year
+}
+
+func (e *dateExtractor) iso(m []string) (dateCandidate, bool) {
+	month, _ := strconv.Atoi(m[3])
+	day, _ := strconv.Atoi(m[5])
+	t, ok := e.date(e.year(m[1]), time.Month(month), day)
+	ok = ok && m[2] == m[4]
+	c := dateCandidate{DateMatch: DateMatch{Confidence: 0.95}, hasYear: true}
+	if ok && m[6] != "" {
+		hour, _ := strconv.Atoi(m[6])
+		minute, _ := strconv.Atoi(m[7])
+		second, _ := strconv.Atoi(m[8])
+		if hour > 23 || minute > 59 || second > 59 {
+			// the date is kept without the invalid time of day
+			c.End = e.loc[11] - e.loc[0]
+		} else {
+			t = t.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+				time.Duration(second)*time.Second)
+		}
+	}
+	c.Time = t
+	return c, ok
+}
+
+func (e *dateExtractor) numeric(m []string) (dateCandidate, bool) {
+	first, _ := strconv.Atoi(m[1])
+	second, _ := strconv.Atoi(m[3])
+	day, month := first, second
+	confidence := 0.8
+	switch {
+	case first > 12:
+	case second > 12:
+		day, month = second, first
+	case first == second:
+	case e.opts.DayFirst:
+		confidence = 0.5
+	default:
+		day, month = second, first
+		confidence = 0.5
+	}
+	t, ok := e.date(e.year(m[5]), time.Month(month), day)
+	ok = ok && m[2] == m[4]
+	if len(m[5]) == 2 {
+		confidence -= 0.1
+	}
+	return dateCandidate{DateMatch: DateMatch{Time: t, Confidence: confidence}, hasYear: true}, ok
+}
+
+func (e *dateExtractor) dayMonth(m []string) (dateCandidate, bool) {
+	return e.textual(m[1], m[2], m[3], m[4])
+}
+
+func (e *dateExtractor) monthDay(m []string) (dateCandidate, bool) {
+	if m[1] == "may" && m[4] == "" && e.loc[0] == e.loc[2] && !e.capitalized(e.loc[2]) &&
+		!hasOrdinalSuffix(e.text[e.loc[5]:]) {
+		// "may" followed by a number is rather the verb, as in "I may 2 go"
+		return dateCandidate{}, false
+	}
+	return e.textual(m[2], m[3], m[1], m[4])
+}
+
+// capitalized checks if the word at given offset of text is capitalized in the source.
+func (e *dateExtractor) capitalized(i int) bool {
+	start, _ := e.offsets.ByteRange(i, i)
+	r, _ := utf8.DecodeRuneInString(e.source[start:])
+	return unicode.IsUpper(r)
+}
+
+func hasOrdinalSuffix(s string) bool {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasPrefix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// textual builds a date or a range of days from the parts of a date with a month name.
+func (e *dateExtractor) textual(day, lastDay, month, year string) (dateCandidate, bool) {
+	c := dateCandidate{DateMatch: DateMatch{Confidence: 0.9}, hasYear: year != ""}
+	y := e.opts.Reference.Year()
+	if c.hasYear {
+		y = e.year(year)
+	} else {
+		c.Confidence = 0.7
+	}
+	d, _ := strconv.Atoi(day)
+	t, ok := e.date(y, e.patterns.months[month], d)
+	if !ok {
+		return c, false
+	}
+	c.Time = t
+	if lastDay != "" {
+		d, _ = strconv.Atoi(lastDay)
+		if c.Until, ok = e.date(y, e.patterns.months[month], d); !ok || !c.Until.After(c.Time) {
+			return c, false
+		}
+	}
+	return c, true
+}
+
+func (e *dateExtractor) monthYear(m []string) (dateCandidate, bool) {
+	t, ok := e.date(e.year(m[2]), e.patterns.months[m[1]], 1)
+	c := dateCandidate{DateMatch: DateMatch{Time: t, Confidence: 0.6}, hasYear: true}
+	c.Until = t.AddDate(0, 1, -1)
+	return c, ok
+}
+
+// today returns the midnight of the reference time.
+func (e *dateExtractor) today() time.Time {
+	ref := e.opts.Reference
+	return time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location())
+}
+
+func (e *dateExtractor) relativeDay(m []string) (dateCandidate, bool) {
+	word := strings.Join(strings.Fields(m[1]), " ")
+	t := e.today().AddDate(0, 0, e.patterns.days[word])
+	c := dateCandidate{DateMatch: DateMatch{Time: t, Confidence: 0.9}, hasYear: true}
+	if !strings.Contains(word, " ") && strings.TrimSpace(e.text) != m[1] {
+		// single words such as "morgen" or "hier" may mean something else in a sentence
+		c.Confidence = 0.5
+	}
+	return c, true
+}
+
+// relative returns a function building dates from the relative expressions of given language.
+func (e *dateExtractor) relative(l *dateLanguage) func(m []string) (dateCandidate, bool) {
+	numbers := numberLanguages[l.lang]
+	return func(m []string) (dateCandidate, bool) {
+		word, number, unit := m[1], m[2], m[3]
+		if _, ok := l.units[number]; ok {
+			// the expression follows the number, e.g. "3 days ago"
+			number, unit, word = m[1], m[2], m[3]
+		}
+		// drop the words preceding the number, e.g. "we left" in "we left 3 days ago"
+		rest := number
+		var count int64
+		found := false
+		for rest != "" && !found {
+			words := strings.Fields(rest)
+			phrase := strings.Join(words, " ")
+			if n, err := strconv.Atoi(phrase); err == nil {
+				count, found = int64(n), true
+			} else if n, ordinal, ok := numbers.parse(phrase); ok && !ordinal {
+				count, found = n, true
+			} else if len(words) == 1 && containsString(l.ones, phrase) {
+				count, found = 1, true
+			} else {
+				rest = strings.TrimLeft(rest[strings.Index(rest, words[0])+len(words[0]):], " ")
+			}
+		}
+		if !found || count > 10000 {
+			return dateCandidate{}, false
+		}
+		sign := 1
+		if containsString(l.pastBefore, word) || containsString(l.pastAfter, word) {
+			sign = -1
+		}
+		u := l.units[unit]
+		n := sign * int(count)
+		t := e.today().AddDate(n*u.years, n*u.months, n*u.days)
+		c := dateCandidate{DateMatch: DateMatch{Time: t, Confidence: 0.8}, hasYear: true}
+		if _, ok := l.units[m[2]]; ok {
+			// the number starts the match
+			c.Start = len(number) - len(rest)
+		}
+		return c, true
+	}
+}
+
+func containsString(ls []string, s string) bool {
+	for _, x := range ls {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ranges joins the dates separated by a range connector, such as "from 21 March to 2 April".
+func (e *dateExtractor) ranges(candidates []dateCandidate) []dateCandidate {
+	var joined []dateCandidate
+	for i := 0; i < len(candidates); i++ {
+		c := candidates[i]
+		if i+1 < len(candidates) && c.Until.IsZero() && candidates[i+1].Until.IsZero() {
+			if r, ok := e.join(c, candidates[i+1]); ok {
+				joined = append(joined, r)
+				i++
+				continue
+			}
+		}
+		joined = append(joined, c)
+	}
+	return joined
+}
+
+func (e *dateExtractor) join(first, last dateCandidate) (dateCandidate, bool) {
+	connector := strings.TrimSpace(e.text[first.End:last.Start])
+	connector = strings.TrimSpace(strings.Replace(connector, "'", "", -1))
+	if !e.patterns.connectors[connector] {
+		return first, false
+	}
+	start := first.Start
+	before := strings.Fields(e.text[:first.Start])
+	hasOpener := len(before) > 0 && e.patterns.openers[before[len(before)-1]]
+	if hasOpener {
+		start = strings.LastIndex(e.text[:first.Start], before[len(before)-1])
+	}
+	if datePairs[connector] && !hasOpener {
+		return first, false
+	}
+	switch {
+	case !first.hasYear && last.hasYear:
+		// "21 March to 2 April 2018" has the year of the last date
+		first.Time = first.Time.AddDate(last.Time.Year()-first.Time.Year(), 0, 0)
+		if first.Time.After(last.Time) {
+			first.Time = first.Time.AddDate(-1, 0, 0)
+		}
+	case !last.hasYear:
+		// "December 31 to January 2" ends in the year following the first date
+		last.Time = last.Time.AddDate(first.Time.Year()-last.Time.Year(), 0, 0)
+		if last.Time.Month() < first.Time.Month() {
+			last.Time = last.Time.AddDate(1, 0, 0)
+		}
+	}
+	if !last.Time.After(first.Time) {
+		return first, false
+	}
+	r := dateCandidate{hasYear: first.hasYear || last.hasYear}
+	r.Start, r.End = start, last.End
+	r.Time, r.Until = first.Time, last.Time
+	r.Confidence = minFloat(first.Confidence, last.Confidence)
+	return r, true
+}
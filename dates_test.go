@@ -0,0 +1,111 @@
+package agstring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractDates(t *testing.T) {
+	ref := time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC)
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		input    string
+		text     string
+		time     time.Time
+		until    time.Time
+		dayFirst bool
+		lang     string
+	}{
+		{"at 2018-03-21 14:30 in room 3", "2018-03-21 14:30", day(2018, 3, 21).Add(14*time.Hour + 30*time.Minute), time.Time{}, false, ""},
+		{"at 2018-03-21 25:30", "2018-03-21", day(2018, 3, 21), time.Time{}, false, ""},
+		{"due 21.03.2018.", "21.03.2018", day(2018, 3, 21), time.Time{}, false, ""},
+		{"due 3/4/2020", "3/4/2020", day(2020, 3, 4), time.Time{}, false, ""},
+		{"due 3/4/2020", "3/4/2020", day(2020, 4, 3), time.Time{}, true, ""},
+		{"due 13/04/20", "13/04/20", day(2020, 4, 13), time.Time{}, false, ""},
+		{"Mon 21st of March 2018", "Mon 21st of March 2018", day(2018, 3, 21), time.Time{}, false, ""},
+		{"on March 21st, 2018", "March 21st, 2018", day(2018, 3, 21), time.Time{}, false, ""},
+		{"due May 2", "May 2", day(2020, 5, 2), time.Time{}, false, ""},
+		{"due may 2nd", "may 2nd", day(2020, 5, 2), time.Time{}, false, ""},
+		{"due may 2, 2018", "may 2, 2018", day(2018, 5, 2), time.Time{}, false, ""},
+		{"March 21-23, 2018", "March 21-23, 2018", day(2018, 3, 21), day(2018, 3, 23), false, ""},
+		{"am 21. März 2018", "21. März 2018", day(2018, 3, 21), time.Time{}, false, "de"},
+		{"le 3 août", "3 août", day(2020, 8, 3), time.Time{}, false, "fr"},
+		{"el 21 de marzo de 2018", "21 de marzo de 2018", day(2018, 3, 21), time.Time{}, false, "es"},
+		{"23 Nisan 2019 Salı", "23 Nisan 2019", day(2019, 4, 23), time.Time{}, false, "tr"},
+		{"in March 2018 it rained", "March 2018", day(2018, 3, 1), day(2018, 3, 31), false, ""},
+		{"from 21 March to 2 April 2018", "from 21 March to 2 April 2018", day(2018, 3, 21), day(2018, 4, 2), false, ""},
+		{"December 31 - January 2", "December 31 - January 2", day(2020, 12, 31), day(2021, 1, 2), false, ""},
+		{"from 30 Dec 2019 to 2 Jan", "from 30 Dec 2019 to 2 Jan", day(2019, 12, 30), day(2020, 1, 2), false, ""},
+		{"Mars 2030", "Mars 2030", day(2030, 3, 1), day(2030, 3, 31), false, "fr"},
+		{"am 3. Mai", "3. Mai", day(2020, 5, 3), time.Time{}, false, "de-AT"},
+		{"between 1/3/2018 and 5/3/2018", "between 1/3/2018 and 5/3/2018", day(2018, 3, 1), day(2018, 3, 5), true, ""},
+		{"yesterday", "yesterday", day(2020, 6, 14), time.Time{}, false, ""},
+		{"the day after tomorrow", "the day after tomorrow", day(2020, 6, 17), time.Time{}, false, ""},
+		{"we left three days ago", "three days ago", day(2020, 6, 12), time.Time{}, false, ""},
+		{"back in two weeks", "in two weeks", day(2020, 6, 29), time.Time{}, false, ""},
+		{"a month ago", "a month ago", day(2020, 5, 15), time.Time{}, false, ""},
+		{"vor drei Tagen", "vor drei Tagen", day(2020, 6, 12), time.Time{}, false, "de"},
+		{"il y a 2 jours", "il y a 2 jours", day(2020, 6, 13), time.Time{}, false, "fr"},
+		{"hace una semana", "hace una semana", day(2020, 6, 8), time.Time{}, false, "es"},
+		{"2 hafta sonra", "2 hafta sonra", day(2020, 6, 29), time.Time{}, false, "tr"},
+	}
+
+	for _, testCase := range testCases {
+		opts := DateOptions{Reference: ref, DayFirst: testCase.dayFirst}
+		if testCase.lang != "" {
+			opts.Languages = []string{testCase.lang}
+		}
+		matches := ExtractDates(testCase.input, opts)
+		require.Len(t, matches, 1, "for %q", testCase.input)
+		m := matches[0]
+		require.Equal(t, testCase.text, testCase.input[m.Start:m.End], "for %q", testCase.input)
+		require.Equal(t, testCase.time, m.Time, "for %q", testCase.input)
+		require.Equal(t, testCase.until, m.Until, "for %q", testCase.input)
+	}
+
+	for _, input := range []string{
+		"", "2018-13-01", "version 1.2", "in two", "may I come", "I may 2 go", "30/02/2018",
+		"Ich bin hier", "Guten Morgen", "dun", "hoy", "Mars 2030",
+	} {
+		require.Empty(t, ExtractDates(input, DateOptions{Reference: ref}), "for %q", input)
+	}
+}
+
+func TestExtractDatesConfidence(t *testing.T) {
+	ref := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	matches := ExtractDates("2018-03-21, 21 March 2018, 21 March, 3/4/2020", DateOptions{Reference: ref})
+	require.Len(t, matches, 4)
+	for i := 1; i < len(matches); i++ {
+		require.True(t, matches[i].Confidence < matches[i-1].Confidence, "for %d", i)
+	}
+}
+
+func TestExtractDatesRelativeConfidence(t *testing.T) {
+	ref := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	opts := DateOptions{Reference: ref, Languages: []string{"de"}}
+	matches := ExtractDates("Guten Morgen", opts)
+	require.Len(t, matches, 1)
+	require.Equal(t, 0.5, matches[0].Confidence)
+	matches = ExtractDates("morgen", opts)
+	require.Len(t, matches, 1)
+	require.Equal(t, 0.9, matches[0].Confidence)
+	matches = ExtractDates("vorgestern war es kalt", opts)
+	require.Len(t, matches, 1)
+	require.Equal(t, 0.5, matches[0].Confidence)
+}
+
+func TestParseDate(t *testing.T) {
+	ref := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	actual, err := ParseDate(" 21 March 2018 ", DateOptions{Reference: ref})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2018, 3, 21, 0, 0, 0, 0, time.UTC), actual)
+
+	for _, input := range []string{"", "on 21 March 2018", "21-23 March 2018", "foo"} {
+		_, err = ParseDate(input, DateOptions{Reference: ref})
+		require.Error(t, err, "for %q", input)
+	}
+}
@@ -0,0 +1,185 @@
+package agstring
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// NormalizationForm is a Unicode normalization form.
+type NormalizationForm int
+
+// Unicode normalization forms
+const (
+	NFC NormalizationForm = iota
+	NFD
+	NFKC
+	NFKD
+)
+
+var normForms = map[NormalizationForm]norm.Form{
+	NFC:  norm.NFC,
+	NFD:  norm.NFD,
+	NFKC: norm.NFKC,
+	NFKD: norm.NFKD,
+}
+
+func (f NormalizationForm) String() string {
+	switch f {
+	case NFC:
+		return "NFC"
+	case NFD:
+		return "NFD"
+	case NFKC:
+		return "NFKC"
+	case NFKD:
+		return "NFKD"
+	}
+	return "NormalizationForm(?)"
+}
+
+// ToNormalizationForm converts given string to given Unicode normalization form. Unknown forms
+// leave the string unchanged.
+func ToNormalizationForm(s string, form NormalizationForm) string {
+	if f, ok := normForms[form]; ok {
+		return f.String(s)
+	}
+	return s
+}
+
+// FoldOption selects the foldings applied by Fold. Options are combined with `|`.
+type FoldOption uint
+
+// Foldings applied by Fold
+const (
+	// FoldCompatibility applies the Unicode compatibility mappings (NFKC), e.g. "①" becomes
+	// "1" and "ｶ" becomes "カ". It includes most of the width and ligature foldings.
+	FoldCompatibility FoldOption = 1 << iota
+	// FoldWidth maps full-width Latin letters, digits and symbols to their ASCII forms and
+	// half-width Katakana to full-width, e.g. "ＡＢＣ" becomes "ABC".
+	FoldWidth
+	// FoldCase applies Unicode case folding, e.g. "Straße" becomes "strasse".
+	FoldCase
+	// FoldDiacritics removes diacritical marks, e.g. "Łódź" becomes "Łodz". Only general
+	// purpose combining marks and Arabic and Hebrew vowel points are removed; marks which are
+	// part of letters, such as Japanese voicing marks, Indic vowel signs or the marks of
+	// Cyrillic "й" and "ё", are kept.
+	FoldDiacritics
+	// FoldLigatures splits typographic ligatures and digraphs, e.g. "ﬁ" becomes "fi" and "Ǆ"
+	// becomes "DŽ". Letters such as "æ" and "œ" are not ligatures and are kept.
+	FoldLigatures
+)
+
+// FoldMatchKey holds the foldings suitable for building match keys without transliterating
+// non-Latin scripts.
+const FoldMatchKey = FoldWidth | FoldCase | FoldDiacritics | FoldLigatures
+
+var foldOptionNames = []struct {
+	opt  FoldOption
+	name string
+}{
+	{FoldCompatibility, "Compatibility"},
+	{FoldWidth, "Width"},
+	{FoldCase, "Case"},
+	{FoldDiacritics, "Diacritics"},
+	{FoldLigatures, "Ligatures"},
+}
+
+func (o FoldOption) String() string {
+	var names []string
+	for _, n := range foldOptionNames {
+		if o&n.opt != 0 {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, "|")
+}
+
+// Fold applies the selected foldings to given string. Unlike RemoveDiacritics it never
+// transliterates, so non-Latin scripts are kept intact. The result is in NFC, or NFKC when
+// FoldCompatibility is selected.
+func Fold(s string, opts FoldOption) string {
+	if opts&FoldWidth != 0 {
+		s = width.Fold.String(s)
+	}
+	if opts&FoldLigatures != 0 {
+		s = foldLigatures(s)
+	}
+	if opts&FoldCompatibility != 0 {
+		s = norm.NFKC.String(s)
+	}
+	if opts&FoldDiacritics != 0 {
+		s = removeDiacritics(norm.NFD.String(s))
+	}
+	if opts&FoldCase != 0 {
+		s = cases.Fold().String(s)
+	}
+	if opts&FoldCompatibility != 0 {
+		return norm.NFKC.String(s)
+	}
+	return norm.NFC.String(s)
+}
+
+// Fold appends a Fold step with given options.
+func (p *Pipeline) Fold(opts FoldOption) *Pipeline {
+	return p.Then(stepName("Fold", opts.String()), func(s string) string {
+		return Fold(s, opts)
+	})
+}
+
+var diacritics = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0300, Hi: 0x036f, Stride: 1}, // Combining Diacritical Marks
+		{Lo: 0x0591, Hi: 0x05bd, Stride: 1}, // Hebrew accents and points
+		{Lo: 0x05bf, Hi: 0x05bf, Stride: 1}, // Hebrew points
+		{Lo: 0x05c1, Hi: 0x05c2, Stride: 1},
+		{Lo: 0x05c4, Hi: 0x05c5, Stride: 1},
+		{Lo: 0x05c7, Hi: 0x05c7, Stride: 1},
+		{Lo: 0x064b, Hi: 0x065f, Stride: 1}, // Arabic tashkeel
+		{Lo: 0x0670, Hi: 0x0670, Stride: 1},
+		{Lo: 0x1ab0, Hi: 0x1aff, Stride: 1}, // Combining Diacritical Marks Extended
+		{Lo: 0x1dc0, Hi: 0x1dff, Stride: 1}, // Combining Diacritical Marks Supplement
+		{Lo: 0x20d0, Hi: 0x20ff, Stride: 1}, // Combining Diacritical Marks for Symbols
+		{Lo: 0xfe20, Hi: 0xfe2f, Stride: 1}, // Combining Half Marks
+	},
+}
+
+func isDiacritic(r rune) bool { return unicode.Is(diacritics, r) }
+
+// removeDiacritics removes the diacritics of given NFD string, except those following Cyrillic
+// letters, which make other letters such as "й".
+func removeDiacritics(s string) string {
+	var base rune
+	return strings.Map(func(r rune) rune {
+		if !unicode.Is(unicode.Mn, r) {
+			base = r
+		}
+		if isDiacritic(r) && !unicode.Is(unicode.Cyrillic, base) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isLigature(r rune) bool {
+	return 0xfb00 <= r && r <= 0xfb06 || 0xfb13 <= r && r <= 0xfb17 || r == 0x0132 || r == 0x0133 ||
+		0x01c4 <= r && r <= 0x01cc || 0x01f1 <= r && r <= 0x01f3
+}
+
+func foldLigatures(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if isLigature(r) {
+			b.WriteString(norm.NFKC.String(string(r)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToNormalizationForm(t *testing.T) {
+	composed, decomposed := "é", "é"
+	testCases := []struct {
+		input    string
+		form     NormalizationForm
+		expected string
+	}{
+		{decomposed, NFC, composed},
+		{composed, NFD, decomposed},
+		{"ﬁ①", NFC, "ﬁ①"},
+		{"ﬁ①", NFKC, "fi1"},
+		{"ﬁ" + composed, NFKD, "fi" + decomposed},
+		{composed, NormalizationForm(42), composed},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, ToNormalizationForm(testCase.input, testCase.form),
+			"for %q in %s", testCase.input, testCase.form)
+	}
+}
+
+func TestFold(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     FoldOption
+		expected string
+	}{
+		{"ＡＢＣ１２３", FoldWidth, "ABC123"},
+		{"ｶﾞｷﾞ", FoldWidth, "ガギ"},
+		{"ＡＢＣ", FoldCase, "ａｂｃ"},
+		{"Straße ΣΊΣΥΦΟΣ", FoldCase, "strasse σίσυφοσ"},
+		{"Łódź Çağ", FoldDiacritics, "Łodz Cag"},
+		{"été", FoldDiacritics, "ete"},
+		{"がぎぐ", FoldDiacritics, "がぎぐ"},
+		{"مُحَمَّد", FoldDiacritics, "محمد"},
+		{"हिन्दी", FoldDiacritics, "हिन्दी"},
+		{"йод ёлка Київ", FoldDiacritics, "йод ёлка Київ"},
+		{"Ελλάδα", FoldDiacritics, "Ελλαδα"},
+		{"ﬁnance Ǆ Ĳssel æ", FoldLigatures, "finance DŽ IJssel æ"},
+		{"①ｶ㎏", FoldCompatibility, "1カkg"},
+		{"ＴＯＫＹＯ 東京 ﬁ Café", FoldMatchKey, "tokyo 東京 fi cafe"},
+		{"Café", 0, "Café"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Fold(testCase.input, testCase.opts),
+			"for %q with %s", testCase.input, testCase.opts)
+	}
+}
+
+func TestFoldOptionString(t *testing.T) {
+	require.Equal(t, "None", FoldOption(0).String())
+	require.Equal(t, "Width|Case|Diacritics|Ligatures", FoldMatchKey.String())
+
+	p := NewPipeline().Fold(FoldCase | FoldWidth)
+	require.Equal(t, `Fold("Width|Case")`, p.String())
+	require.Equal(t, "abc", p.Apply("ＡＢＣ"))
+}
@@ -0,0 +1,139 @@
+package agstring
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// DefaultEllipsis is the ellipsis commonly appended to truncated strings.
+const DefaultEllipsis = "…"
+
+// Graphemes splits given string into user-perceived characters, i.e. extended grapheme
+// clusters as defined by UAX #29. For example "é", "🇹🇷" and "👩‍💻" are single graphemes.
+func Graphemes(s string) []string {
+	var gs []string
+	state := -1
+	for len(s) > 0 {
+		var g string
+		g, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// GraphemeCount returns the number of user-perceived characters in given string.
+func GraphemeCount(s string) int { return uniseg.GraphemeClusterCount(s) }
+
+// graphemeOffset returns the byte offset of n-th grapheme of s, or len(s) if s is shorter.
+func graphemeOffset(s string, n int) int {
+	offset, state := 0, -1
+	for ; n > 0 && offset < len(s); n-- {
+		var g string
+		g, _, _, state = uniseg.FirstGraphemeClusterInString(s[offset:], state)
+		offset += len(g)
+	}
+	return offset
+}
+
+// TakeGraphemesTo is similar to TakeTo but truncates each string in the input slice up to `n`
+// user-perceived characters, so that emoji sequences, flags and combining marks are not split.
+func TakeGraphemesTo(ls []string, n int) []string {
+	out := make([]string, 0, len(ls))
+	for _, s := range ls {
+		out = append(out, s[:graphemeOffset(s, n)])
+	}
+	return out
+}
+
+// TakeGraphemesFrom is similar to TakeFrom but removes the first `n` user-perceived characters
+// from each string in the input slice.
+func TakeGraphemesFrom(ls []string, n int) []string {
+	out := make([]string, 0, len(ls))
+	for _, s := range ls {
+		out = append(out, s[graphemeOffset(s, n):])
+	}
+	return out
+}
+
+// TruncateOptions configures Truncate. Zero budgets are unlimited.
+type TruncateOptions struct {
+	// MaxWidth is the maximum display width of the result in terminal cells.
+	MaxWidth int
+	// MaxBytes is the maximum length of the result in bytes.
+	MaxBytes int
+	// Ellipsis is appended to truncated strings, e.g. DefaultEllipsis. It counts towards the
+	// budgets.
+	Ellipsis string
+	// BreakWords allows cutting anywhere between graphemes. By default strings are cut at the
+	// last word boundary fitting the budgets, unless that would leave no words.
+	BreakWords bool
+	// AmbiguousWide measures characters of ambiguous East Asian width as two cells. See
+	// WidthOptions.
+	AmbiguousWide bool
+}
+
+// Truncate cuts given string to fit the budgets of given options and appends the ellipsis if
+// the string is cut. Strings are never cut inside a grapheme.
+func Truncate(s string, opts TruncateOptions) string {
+	return truncate(s, opts, WidthOptions{AmbiguousWide: opts.AmbiguousWide})
+}
+
+func truncate(s string, opts TruncateOptions, wo WidthOptions) string {
+	if fitsBudget(s, opts.MaxWidth, opts.MaxBytes, wo) {
+		return s
+	}
+	ellipsis := opts.Ellipsis
+	if !fitsBudget(ellipsis, opts.MaxWidth, opts.MaxBytes, wo) {
+		ellipsis = ""
+	}
+	maxWidth, maxBytes := opts.MaxWidth-wo.Width(ellipsis), opts.MaxBytes-len(ellipsis)
+
+	cut, width, state := 0, 0, -1
+	for cut < len(s) {
+		g, _, w, newState := uniseg.FirstGraphemeClusterInString(s[cut:], state)
+		w = wo.graphemeWidth(g, w)
+		if opts.MaxWidth > 0 && width+w > maxWidth || opts.MaxBytes > 0 && cut+len(g) > maxBytes {
+			break
+		}
+		cut, width, state = cut+len(g), width+w, newState
+	}
+	if !opts.BreakWords {
+		cut = lastWordBoundary(s, cut)
+	}
+	return strings.TrimRightFunc(s[:cut], unicode.IsSpace) + ellipsis
+}
+
+// Truncate appends a Truncate step with given options.
+func (p *Pipeline) Truncate(opts TruncateOptions) *Pipeline {
+	return p.Then(stepName("Truncate", fmt.Sprintf("%+v", opts)), func(s string) string {
+		return Truncate(s, opts)
+	})
+}
+
+func fitsBudget(s string, maxWidth, maxBytes int, wo WidthOptions) bool {
+	return (maxBytes <= 0 || len(s) <= maxBytes) && (maxWidth <= 0 || wo.Width(s) <= maxWidth)
+}
+
+// lastWordBoundary returns the last word boundary of s not after cut keeping some non-space
+// text, or cut when there is no such boundary.
+func lastWordBoundary(s string, cut int) int {
+	boundary, offset, state := 0, 0, -1
+	for offset < cut {
+		var word string
+		word, _, state = uniseg.FirstWordInString(s[offset:], state)
+		if offset+len(word) > cut {
+			break
+		}
+		offset += len(word)
+		if strings.TrimSpace(s[:offset]) != "" {
+			boundary = offset
+		}
+	}
+	if boundary == 0 {
+		return cut
+	}
+	return boundary
+}
@@ -0,0 +1,90 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphemes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"abc", []string{"a", "b", "c"}},
+		{"été", []string{"é", "t", "é"}},
+		{"🇹🇷🇩🇪", []string{"🇹🇷", "🇩🇪"}},
+		{"👩‍💻!", []string{"👩‍💻", "!"}},
+		{"👍🏽", []string{"👍🏽"}},
+		{"\r\n", []string{"\r\n"}},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Graphemes(testCase.input), "for %q", testCase.input)
+		require.Equal(t, len(testCase.expected), GraphemeCount(testCase.input))
+	}
+}
+
+func TestTakeGraphemesTo(t *testing.T) {
+	testCases := []struct {
+		input    []string
+		n        int
+		expected []string
+	}{
+		{[]string{"ąćęłńóśźż", "ĄĆĘŁŃÓŚŹŻ"}, 5, []string{"ąćęłń", "ĄĆĘŁŃ"}},
+		{[]string{"été", "hello"}, 1, []string{"é", "h"}},
+		{[]string{"🇹🇷🇩🇪", "👩‍💻👩‍💻"}, 1, []string{"🇹🇷", "👩‍💻"}},
+		{[]string{"ドンキーコング", "hello"}, 100, []string{"ドンキーコング", "hello"}},
+		{[]string{"abc"}, 0, []string{""}},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, TakeGraphemesTo(testCase.input, testCase.n))
+	}
+}
+
+func TestTakeGraphemesFrom(t *testing.T) {
+	testCases := []struct {
+		input    []string
+		n        int
+		expected []string
+	}{
+		{[]string{"ąćęłńóśźż", "ĄĆĘŁŃÓŚŹŻ"}, 5, []string{"óśźż", "ÓŚŹŻ"}},
+		{[]string{"été", "hello"}, 1, []string{"té", "ello"}},
+		{[]string{"🇹🇷🇩🇪", "👩‍💻👩‍💻"}, 1, []string{"🇩🇪", "👩‍💻"}},
+		{[]string{"ドンキーコング", "hello"}, 100, []string{"", ""}},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, TakeGraphemesFrom(testCase.input, testCase.n))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     TruncateOptions
+		expected string
+	}{
+		{"hello world", TruncateOptions{}, "hello world"},
+		{"hello world", TruncateOptions{MaxWidth: 11, Ellipsis: DefaultEllipsis}, "hello world"},
+		{"hello world", TruncateOptions{MaxWidth: 10, Ellipsis: DefaultEllipsis}, "hello…"},
+		{"hello world", TruncateOptions{MaxWidth: 10, Ellipsis: DefaultEllipsis, BreakWords: true}, "hello wor…"},
+		{"hello world", TruncateOptions{MaxWidth: 8, Ellipsis: "..."}, "hello..."},
+		{"hello, big world", TruncateOptions{MaxWidth: 12}, "hello, big"},
+		{"helloworld", TruncateOptions{MaxWidth: 6, Ellipsis: DefaultEllipsis}, "hello…"},
+		{"ドンキーコング", TruncateOptions{MaxWidth: 7, Ellipsis: DefaultEllipsis}, "ドンキ…"},
+		{"été été", TruncateOptions{MaxBytes: 9, BreakWords: true}, "été"},
+		{"été", TruncateOptions{MaxBytes: 4, BreakWords: true}, "ét"},
+		{"🇹🇷🇩🇪", TruncateOptions{MaxBytes: 10}, "🇹🇷"},
+		{"👩‍💻👩‍💻", TruncateOptions{MaxWidth: 3}, "👩‍💻"},
+		{"hello world", TruncateOptions{MaxWidth: 1, Ellipsis: "..."}, "h"},
+		{"hello world", TruncateOptions{MaxWidth: 3, Ellipsis: "..."}, "..."},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Truncate(testCase.input, testCase.opts),
+			"for %q with %+v", testCase.input, testCase.opts)
+	}
+}
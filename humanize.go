@@ -0,0 +1,439 @@
+package agstring
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ByteUnits selects the multiples of byte sizes.
+type ByteUnits int
+
+// Byte units
+const (
+	// BytesSI uses powers of 1000: kB, MB, GB...
+	BytesSI ByteUnits = iota
+	// BytesIEC uses powers of 1024: KiB, MiB, GiB...
+	BytesIEC
+)
+
+// ByteFormat configures FormatBytes.
+type ByteFormat struct {
+	Units ByteUnits
+	// Precision is the maximum number of decimals, e.g. 1 for "1.5 GB". Trailing zeros are
+	// dropped.
+	Precision int
+	// Long spells units out, e.g. "1.5 gigabytes" rather than "1.5 GB".
+	Long bool
+}
+
+var byteUnitNames = [2][]struct{ short, long string }{
+	{{"B", "byte"}, {"kB", "kilobyte"}, {"MB", "megabyte"}, {"GB", "gigabyte"}, {"TB", "terabyte"},
+		{"PB", "petabyte"}, {"EB", "exabyte"}},
+	{{"B", "byte"}, {"KiB", "kibibyte"}, {"MiB", "mebibyte"}, {"GiB", "gibibyte"}, {"TiB", "tebibyte"},
+		{"PiB", "pebibyte"}, {"EiB", "exbibyte"}},
+}
+
+// FormatBytes writes given byte size with the largest unit it reaches, e.g. "1.5 GB".
+func FormatBytes(n int64, f ByteFormat) string {
+	base := 1000.0
+	if f.Units == BytesIEC {
+		base = 1024
+	}
+	names := byteUnitNames[f.Units]
+	v := math.Abs(float64(n))
+	i := 0
+	for ; i < len(names)-1 && v >= base; i++ {
+		v /= base
+	}
+	number := formatDecimals(v, f.Precision)
+	if number == formatDecimals(base, f.Precision) && i < len(names)-1 {
+		// rounded up to the next unit, e.g. 999999 bytes to "1000 kB"
+		i++
+		number = formatDecimals(v/base, f.Precision)
+	}
+	if n < 0 {
+		number = "-" + number
+	}
+	name := names[i].short
+	if f.Long {
+		name = names[i].long
+		if number != "1" {
+			name += "s"
+		}
+	}
+	return number + " " + name
+}
+
+// formatDecimals writes v with given maximum number of decimals.
+func formatDecimals(v float64, precision int) string {
+	s := strconv.FormatFloat(v, 'f', maxInt(precision, 0), 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+var (
+	byteSizeRegexp = regexp.MustCompile(`^([-−+]?[\p{Nd}.,]+)\s*([a-zA-Z]*)$`)
+	// bytePowers maps lower cased byte units to their powers of 1000 or 1024, and binaryUnit
+	// holds the units always meaning powers of 1024, such as "kib".
+	bytePowers = make(map[string]int)
+	binaryUnit = make(map[string]bool)
+)
+
+func init() {
+	for power, prefix := range []string{"", "k", "m", "g", "t", "p", "e"} {
+		long := byteUnitNames[BytesSI][power].long
+		binaryLong := byteUnitNames[BytesIEC][power].long
+		for _, unit := range []string{prefix, prefix + "b", long, long + "s"} {
+			bytePowers[unit] = power
+		}
+		if power > 0 {
+			for _, unit := range []string{prefix + "i", prefix + "ib", binaryLong, binaryLong + "s"} {
+				bytePowers[unit] = power
+				binaryUnit[unit] = true
+			}
+		}
+	}
+}
+
+// ParseBytes returns the number of bytes of given size, e.g. 1536 for "1.5 KiB". Binary units
+// such as "KiB" and "Ki" are powers of 1024, while units such as "kB", "K" or "kilobytes" are
+// powers of 1000 in SI mode and of 1024 in IEC mode, as they often are for memory. Units are
+// case insensitive, and sizes without units are in bytes. An error is returned for malformed
+// sizes and sizes which overflow an int64.
+func ParseBytes(s string, units ByteUnits) (int64, error) {
+	m := byteSizeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, errors.Errorf("invalid byte size %q", s)
+	}
+	unit := strings.ToLower(m[2])
+	power, ok := bytePowers[unit]
+	if !ok {
+		return 0, errors.Errorf("unknown byte unit %q", m[2])
+	}
+	d, err := SafeParseDecimal(m[1], "")
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid byte size %q", s)
+	}
+	base := int64(1000)
+	if units == BytesIEC || binaryUnit[unit] {
+		base = 1024
+	}
+	n := new(big.Int).Mul(d.Unscaled, new(big.Int).Exp(big.NewInt(base), big.NewInt(int64(power)), nil))
+	// round half away from zero
+	divisor := pow10(d.Scale)
+	half := new(big.Int).Quo(divisor, big.NewInt(2))
+	if n.Sign() < 0 {
+		half.Neg(half)
+	}
+	n.Quo(n.Add(n, half), divisor)
+	if !n.IsInt64() {
+		return 0, errors.Errorf("byte size %q out of range", s)
+	}
+	return n.Int64(), nil
+}
+
+// DurationStyle selects how durations are written.
+type DurationStyle int
+
+// Duration styles
+const (
+	// DurationWords writes durations in English words, e.g. "1 day 4 hours".
+	DurationWords DurationStyle = iota
+	// DurationShort writes durations with unit symbols, e.g. "1d 4h".
+	DurationShort
+	// DurationGo writes durations in Go syntax, e.g. "28h0m0s".
+	DurationGo
+	// DurationISO writes ISO 8601 durations, e.g. "P1DT4H".
+	DurationISO
+)
+
+// DurationFormat configures FormatHumanDuration.
+type DurationFormat struct {
+	Style DurationStyle
+	// Precision is the maximum number of units written, rounding the rest, e.g. 2 for "1 day 4
+	// hours" rather than "1 day 4 hours 3 minutes". Zero writes all units.
+	Precision int
+}
+
+var durationUnits = []struct {
+	size         time.Duration
+	word, symbol string
+}{
+	{24 * time.Hour, "day", "d"},
+	{time.Hour, "hour", "h"},
+	{time.Minute, "minute", "m"},
+	{time.Second, "second", "s"},
+	{time.Millisecond, "millisecond", "ms"},
+	{time.Microsecond, "microsecond", "µs"},
+	{time.Nanosecond, "nanosecond", "ns"},
+}
+
+// FormatHumanDuration writes given duration in given format, e.g. "1 day 4 hours", "1d 4h",
+// "28h0m0s" or "P1DT4H". Days are 24 hours long.
+func FormatHumanDuration(d time.Duration, f DurationFormat) string {
+	// abs is unsigned as negating math.MinInt64 overflows
+	sign, abs := "", uint64(d)
+	if d < 0 {
+		sign, abs = "-", -abs
+	}
+	if f.Precision > 0 {
+		for i, u := range durationUnits {
+			if abs >= uint64(u.size) {
+				last := durationUnits[minInt(i+f.Precision-1, len(durationUnits)-1)].size
+				abs = roundDuration(abs, uint64(last), d < 0)
+				break
+			}
+		}
+	}
+	if f.Style == DurationGo {
+		if d < 0 {
+			return time.Duration(-abs).String()
+		}
+		return time.Duration(abs).String()
+	}
+	if f.Style == DurationISO {
+		return sign + isoDuration(abs)
+	}
+
+	var parts []string
+	for _, u := range durationUnits {
+		n := abs / uint64(u.size)
+		if n == 0 {
+			continue
+		}
+		abs -= n * uint64(u.size)
+		if f.Style == DurationShort {
+			parts = append(parts, strconv.FormatUint(n, 10)+u.symbol)
+		} else if n == 1 {
+			parts = append(parts, "1 "+u.word)
+		} else {
+			parts = append(parts, strconv.FormatUint(n, 10)+" "+u.word+"s")
+		}
+	}
+	if len(parts) == 0 {
+		if f.Style == DurationShort {
+			return "0s"
+		}
+		return "0 seconds"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// roundDuration rounds the magnitude of a duration to a multiple of unit, half away from zero
+// like time.Duration.Round, but down rather than out of the range of time.Duration.
+func roundDuration(abs, unit uint64, negative bool) uint64 {
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	rounded := (abs + unit/2) / unit * unit
+	if rounded > limit {
+		rounded -= unit
+	}
+	return rounded
+}
+
+// isoDuration writes the magnitude of a duration as an ISO 8601 duration with days, hours,
+// minutes and seconds.
+func isoDuration(abs uint64) string {
+	var b strings.Builder
+	b.WriteString("P")
+	if days := abs / uint64(24*time.Hour); days > 0 {
+		b.WriteString(strconv.FormatUint(days, 10) + "D")
+		abs -= days * uint64(24*time.Hour)
+	}
+	if abs > 0 || b.Len() == 1 {
+		b.WriteString("T")
+		if hours := abs / uint64(time.Hour); hours > 0 {
+			b.WriteString(strconv.FormatUint(hours, 10) + "H")
+			abs -= hours * uint64(time.Hour)
+		}
+		if minutes := abs / uint64(time.Minute); minutes > 0 {
+			b.WriteString(strconv.FormatUint(minutes, 10) + "M")
+			abs -= minutes * uint64(time.Minute)
+		}
+		if abs > 0 || b.Len() == 2 {
+			b.WriteString(strconv.FormatFloat(time.Duration(abs).Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return b.String()
+}
+
+var (
+	isoDurationRegexp = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?` +
+		`(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?` +
+		`(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	isoDurationUnits = []time.Duration{
+		365 * 24 * time.Hour, 30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour,
+		time.Minute, time.Second,
+	}
+
+	// durationWords maps English duration units to their sizes. Months and years are 30 and
+	// 365 days long.
+	durationWords = map[string]time.Duration{
+		"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "us": time.Microsecond,
+		"µs": time.Microsecond, "microsecond": time.Microsecond, "ms": time.Millisecond,
+		"msec": time.Millisecond, "millisecond": time.Millisecond, "s": time.Second,
+		"sec": time.Second, "second": time.Second, "m": time.Minute, "min": time.Minute,
+		"minute": time.Minute, "h": time.Hour, "hr": time.Hour, "hour": time.Hour,
+		"d": 24 * time.Hour, "day": 24 * time.Hour, "w": 7 * 24 * time.Hour,
+		"wk": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "mo": 30 * 24 * time.Hour,
+		"month": 30 * 24 * time.Hour, "y": 365 * 24 * time.Hour, "yr": 365 * 24 * time.Hour,
+		"year": 365 * 24 * time.Hour,
+	}
+	durationWordRegexp *regexp.Regexp
+	// durationHalfRegexp matches the half of the preceding unit, as in "an hour and a half".
+	durationHalfRegexp = regexp.MustCompile(`^\s+and\s+a\s+half\b`)
+)
+
+func init() {
+	var words []string
+	for word := range durationWords {
+		words = append(words, word)
+		if utf8.RuneCountInString(word) > 1 && !strings.HasSuffix(word, "s") {
+			words = append(words, word+"s")
+		}
+	}
+	// units end words after spelled out numbers, so "h" doesn't end "two and a half hours" early
+	units := regexpAlternation(words)
+	durationWordRegexp = regexp.MustCompile(`(\d+(?:\.\d+)?\s*)(` + units + `)|` +
+		`(\b[a-z]+(?:[ -][a-z]+)*?\s+)(` + units + `)\b`)
+}
+
+// ParseHumanDuration returns the duration written in given string in English words, such as
+// "1 day 4 hours", "two weeks and 3 days", "an hour and a half" or "90 mins", in Go syntax, such as "2h30m" or "2h
+// 30m", or as an ISO 8601 duration, such as "PT2H30M". Days are 24 hours long, months 30 days
+// and years 365 days.
+func ParseHumanDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty duration")
+	}
+	if m := isoDurationRegexp.FindStringSubmatch(s); m != nil && hasISOComponents(m) && !strings.HasSuffix(s, "T") {
+		return parseISODuration(m)
+	}
+	if d, err := time.ParseDuration(strings.Replace(s, " ", "", -1)); err == nil {
+		return d, nil
+	}
+	if strings.HasPrefix(s, "-") {
+		d, err := parseDurationWords(s[1:])
+		return -d, err
+	}
+	return parseDurationWords(s)
+}
+
+// hasISOComponents checks if the match of isoDurationRegexp has a component, unlike "P".
+func hasISOComponents(m []string) bool {
+	for _, v := range m[2:] {
+		if v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseISODuration(m []string) (time.Duration, error) {
+	var d float64
+	for i, unit := range isoDurationUnits {
+		if v := m[i+2]; v != "" {
+			f, _ := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+			d += f * float64(unit)
+		}
+	}
+	if d > math.MaxInt64 {
+		return 0, errors.Errorf("duration %q out of range", m[0])
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return time.Duration(math.Round(d)), nil
+}
+
+func parseDurationWords(s string) (time.Duration, error) {
+	lower := strings.ToLower(s)
+	var total float64
+	last := 0
+	matches := durationWordRegexp.FindAllStringSubmatchIndex(lower, -1)
+	for _, m := range matches {
+		if r, _ := utf8.DecodeRuneInString(lower[m[1]:]); unicode.IsLetter(r) {
+			return 0, errors.Errorf("invalid duration %q", s)
+		}
+		// groups holds the offsets of the number and the unit
+		groups := m[2:6]
+		if groups[0] < 0 {
+			groups = m[6:10]
+		}
+		number, start := strings.TrimSpace(lower[groups[0]:groups[1]]), groups[0]
+		// drop the connectors preceding the number, e.g. "and" in "and two hours"
+		n, ok := 0.0, false
+		for number != "" {
+			if n, ok = parseDurationNumber(number); ok {
+				break
+			}
+			i := strings.IndexAny(number, " -")
+			if i < 0 {
+				number = ""
+				break
+			}
+			number, start = number[i+1:], start+i+1
+		}
+		if !ok || !isDurationSeparator(lower[last:start]) {
+			return 0, errors.Errorf("invalid duration %q", s)
+		}
+		unit := lower[groups[2]:groups[3]]
+		size, ok := durationWords[unit]
+		if !ok {
+			size = durationWords[strings.TrimSuffix(unit, "s")]
+		}
+		total += n * float64(size)
+		last = m[1]
+		if half := durationHalfRegexp.FindString(lower[last:]); half != "" {
+			total += float64(size) / 2
+			last += len(half)
+		}
+	}
+	if len(matches) == 0 || !isDurationSeparator(lower[last:]) || math.Abs(total) > math.MaxInt64 {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+	return time.Duration(math.Round(total)), nil
+}
+
+// parseDurationNumber parses numbers written with digits or English words, such as "two",
+// "an", "half an" or "two and a half".
+func parseDurationNumber(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	switch s {
+	case "a", "an":
+		return 1, true
+	case "a half", "half a", "half an":
+		return 0.5, true
+	}
+	if whole := strings.TrimSuffix(s, " and a half"); whole != s {
+		n, ok := parseDurationNumber(whole)
+		return n + 0.5, ok
+	}
+	n, ordinal, ok := numberLanguages["en"].parse(s)
+	return float64(n), ok && !ordinal
+}
+
+// isDurationSeparator checks if s only separates the parts of a duration, as ", and " does.
+func isDurationSeparator(s string) bool {
+	for _, field := range strings.Fields(strings.Replace(s, ",", " ", -1)) {
+		if field != "and" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package agstring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatBytes(t *testing.T) {
+	testCases := []struct {
+		n        int64
+		format   ByteFormat
+		expected string
+	}{
+		{0, ByteFormat{}, "0 B"},
+		{512, ByteFormat{Units: BytesIEC}, "512 B"},
+		{1500000000, ByteFormat{Precision: 1}, "1.5 GB"},
+		{1500000000, ByteFormat{}, "2 GB"},
+		{524288, ByteFormat{Units: BytesIEC}, "512 KiB"},
+		{1610612736, ByteFormat{Units: BytesIEC, Precision: 2}, "1.5 GiB"},
+		{999999, ByteFormat{Precision: 1}, "1 MB"},
+		{1000, ByteFormat{Long: true}, "1 kilobyte"},
+		{2048, ByteFormat{Units: BytesIEC, Long: true}, "2 kibibytes"},
+		{-1500, ByteFormat{Precision: 2}, "-1.5 kB"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, FormatBytes(testCase.n, testCase.format))
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		units    ByteUnits
+		expected int64
+	}{
+		{"1.5 GB", BytesSI, 1500000000},
+		{"1.5 GB", BytesIEC, 1610612736},
+		{"512KiB", BytesSI, 524288},
+		{"1,5 kb", BytesSI, 1500},
+		{"2 megabytes", BytesSI, 2000000},
+		{"2 Mi", BytesSI, 2097152},
+		{"42", BytesSI, 42},
+		{"10 bytes", BytesSI, 10},
+		{"1.0005 kB", BytesSI, 1001},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := ParseBytes(testCase.input, testCase.units)
+		require.NoError(t, err, "for %q", testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+	}
+
+	for _, input := range []string{"", "GB", "1.5 parsecs", "1..5 GB", "100 EiB"} {
+		_, err := ParseBytes(input, BytesSI)
+		require.Error(t, err, "for %q", input)
+	}
+}
+
+func TestParseHumanDuration(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"2h 30m", 2*time.Hour + 30*time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"-5m", -5 * time.Minute},
+		{"1 day 4 hours", 28 * time.Hour},
+		{"1d4h", 28 * time.Hour},
+		{"two weeks and 3 days", 17 * 24 * time.Hour},
+		{"an hour, 20 mins and 5 secs", time.Hour + 20*time.Minute + 5*time.Second},
+		{"90 Minutes", 90 * time.Minute},
+		{"3 hrs", 3 * time.Hour},
+		{"PT2H30M", 2*time.Hour + 30*time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"PT0.5S", 500 * time.Millisecond},
+		{"-PT1M", -time.Minute},
+		{"an hour and a half", 90 * time.Minute},
+		{"2 days and a half", 60 * time.Hour},
+		{"two and a half hours", 150 * time.Minute},
+		{"half an hour", 30 * time.Minute},
+		{"a half hour and 5 minutes", 35 * time.Minute},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := ParseHumanDuration(testCase.input)
+		require.NoError(t, err, "for %q", testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+	}
+
+	for _, input := range []string{
+		"", "P", "PT", "-P", "+P", "hours", "5 mice", "1 day or 2", "first day", "2 hours later",
+		"an hour and a half more",
+	} {
+		_, err := ParseHumanDuration(input)
+		require.Error(t, err, "for %q", input)
+	}
+}
+
+func TestFormatHumanDuration(t *testing.T) {
+	d := 28*time.Hour + 3*time.Minute + 20*time.Second
+	testCases := []struct {
+		d        time.Duration
+		format   DurationFormat
+		expected string
+	}{
+		{d, DurationFormat{}, "1 day 4 hours 3 minutes 20 seconds"},
+		{d, DurationFormat{Precision: 2}, "1 day 4 hours"},
+		{d, DurationFormat{Style: DurationShort}, "1d 4h 3m 20s"},
+		{d, DurationFormat{Style: DurationGo, Precision: 3}, "28h3m0s"},
+		{d, DurationFormat{Style: DurationISO}, "P1DT4H3M20S"},
+		{90 * time.Minute, DurationFormat{Precision: 1}, "2 hours"},
+		{1500 * time.Millisecond, DurationFormat{Style: DurationISO}, "PT1.5S"},
+		{-time.Minute, DurationFormat{}, "-1 minute"},
+		{0, DurationFormat{}, "0 seconds"},
+		{0, DurationFormat{Style: DurationISO}, "PT0S"},
+		{48 * time.Hour, DurationFormat{Style: DurationISO}, "P2D"},
+	}
+
+	for _, testCase := range testCases {
+		actual := FormatHumanDuration(testCase.d, testCase.format)
+		require.Equal(t, testCase.expected, actual)
+		parsed, err := ParseHumanDuration(actual)
+		require.NoError(t, err, "for %q", actual)
+		if testCase.format.Precision == 0 {
+			require.Equal(t, testCase.d, parsed, "for %q", actual)
+		}
+	}
+
+	minDuration := time.Duration(math.MinInt64)
+	require.Equal(t, "-106751 days 23 hours 47 minutes 16 seconds 854 milliseconds 775 microseconds 808 nanoseconds",
+		FormatHumanDuration(minDuration, DurationFormat{}))
+	require.Equal(t, "-106751d 23h", FormatHumanDuration(minDuration, DurationFormat{Style: DurationShort, Precision: 2}))
+	require.Equal(t, "-P106751DT23H47M16.854775808S", FormatHumanDuration(minDuration, DurationFormat{Style: DurationISO}))
+	require.Equal(t, minDuration.String(), FormatHumanDuration(minDuration, DurationFormat{Style: DurationGo}))
+	require.Equal(t, "106751 days 23 hours", FormatHumanDuration(math.MaxInt64, DurationFormat{Precision: 2}))
+}
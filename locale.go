@@ -0,0 +1,65 @@
+package agstring
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// ToLowerLocale changes case to lower using the special casing rules of given language tag.
+// For example "tr" and "az" map "I" to "ı" and "İ" to "i", and "lt" keeps the dot above "i"
+// when accents follow it. Unknown tags fall back to the default Unicode case mapping.
+func ToLowerLocale(s, lang string) string {
+	return cases.Lower(language.Make(lang)).String(s)
+}
+
+// ToUpperLocale changes case to upper using the special casing rules of given language tag,
+// e.g. "tr" maps "i" to "İ".
+func ToUpperLocale(s, lang string) string {
+	return cases.Upper(language.Make(lang)).String(s)
+}
+
+// TitleLocale ensures title formatting for given string using the special casing rules of given
+// language tag. Similar to Title, all letters but the first ones of the words are lowered.
+func TitleLocale(s, lang string) string {
+	return cases.Title(language.Make(lang)).String(s)
+}
+
+// NormalizeLocale is similar to Normalize but changes case to lower with the special casing
+// rules of given language tag before removing the diacritics, so that e.g. Turkish "I" folds
+// like "ı" does. When a transliteration profile is registered for the language, it is used
+// instead of the base table, e.g. German "Müller" becomes "mueller".
+func NormalizeLocale(s, lang string) string {
+	s = ToLowerLocale(s, lang)
+	if t := transliteratorFor(lang); t != nil {
+		s = t.Transliterate(s)
+	}
+	return Normalize(s)
+}
+
+// ToLowerLocale appends a ToLowerLocale step with given language tag.
+func (p *Pipeline) ToLowerLocale(lang string) *Pipeline {
+	return p.Then(stepName("ToLowerLocale", lang), func(s string) string {
+		return ToLowerLocale(s, lang)
+	})
+}
+
+// ToUpperLocale appends a ToUpperLocale step with given language tag.
+func (p *Pipeline) ToUpperLocale(lang string) *Pipeline {
+	return p.Then(stepName("ToUpperLocale", lang), func(s string) string {
+		return ToUpperLocale(s, lang)
+	})
+}
+
+// TitleLocale appends a TitleLocale step with given language tag.
+func (p *Pipeline) TitleLocale(lang string) *Pipeline {
+	return p.Then(stepName("TitleLocale", lang), func(s string) string {
+		return TitleLocale(s, lang)
+	})
+}
+
+// NormalizeLocale appends a NormalizeLocale step with given language tag.
+func (p *Pipeline) NormalizeLocale(lang string) *Pipeline {
+	return p.Then(stepName("NormalizeLocale", lang), func(s string) string {
+		return NormalizeLocale(s, lang)
+	})
+}
@@ -0,0 +1,78 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToLowerLocale(t *testing.T) {
+	testCases := []struct {
+		input, lang, expected string
+	}{
+		{"İSTANBUL", "tr", "istanbul"},
+		{"ISPARTA", "tr", "ısparta"},
+		{"ISPARTA", "az", "ısparta"},
+		{"ISPARTA", "en", "isparta"},
+		{"ISPARTA", "", "isparta"},
+		{"ISPARTA", "not a tag", "isparta"},
+		{"I\u00cc", "lt", "ii\u0307\u0300"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t,
+			testCase.expected,
+			ToLowerLocale(testCase.input, testCase.lang),
+			"for %q in %q", testCase.input, testCase.lang,
+		)
+	}
+}
+
+func TestToUpperLocale(t *testing.T) {
+	testCases := []struct {
+		input, lang, expected string
+	}{
+		{"istanbul", "tr", "İSTANBUL"},
+		{"ısparta", "tr", "ISPARTA"},
+		{"istanbul", "az", "İSTANBUL"},
+		{"istanbul", "en", "ISTANBUL"},
+		{"i\u0307\u0300", "lt", "\u00cc"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t,
+			testCase.expected,
+			ToUpperLocale(testCase.input, testCase.lang),
+			"for %q in %q", testCase.input, testCase.lang,
+		)
+	}
+}
+
+func TestTitleLocale(t *testing.T) {
+	testCases := []struct {
+		input, lang, expected string
+	}{
+		{"istanbul ılıca", "tr", "İstanbul Ilıca"},
+		{"İSTANBUL ISPARTA", "tr", "İstanbul Isparta"},
+		{"ISPARTA", "en", "Isparta"},
+		{"abc ABC", "", "Abc Abc"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t,
+			testCase.expected,
+			TitleLocale(testCase.input, testCase.lang),
+			"for %q in %q", testCase.input, testCase.lang,
+		)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	require.Equal(t, "istanbul", NormalizeLocale("İSTANBUL", "tr"))
+	require.Equal(t, "isparta", NormalizeLocale("ISPARTA", "tr"))
+	require.Equal(t, NormalizeLocale("ILICA", "tr"), NormalizeLocale("ılıca", "tr"))
+
+	p := NewPipeline().ReplaceMultispace().TitleLocale("tr")
+	require.Equal(t, "İstanbul Ilıca", p.Apply("  istanbul   ılıca"))
+	require.Equal(t, `ReplaceMultispace -> TitleLocale("tr")`, p.String())
+}
@@ -0,0 +1,299 @@
+package agstring
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MatchKind selects the matches reported by a Matcher.
+type MatchKind int
+
+// Match kinds
+const (
+	// LeftmostLongest reports non-overlapping matches. Of the matches starting at the leftmost
+	// position the longest one is reported, and the search resumes after it.
+	LeftmostLongest MatchKind = iota
+	// Overlapping reports every occurrence of every pattern.
+	Overlapping
+)
+
+// MatcherOptions configures a Matcher.
+type MatcherOptions struct {
+	// Kind selects the reported matches, LeftmostLongest by default.
+	Kind MatchKind
+	// CaseInsensitive matches regardless of letter case.
+	CaseInsensitive bool
+	// DiacriticInsensitive matches regardless of diacritical marks, so "cafe" matches "café".
+	// Letters of other scripts are not transliterated.
+	DiacriticInsensitive bool
+}
+
+// Match is an occurrence of a pattern in a searched string.
+type Match struct {
+	// Pattern is the index of the matched pattern.
+	Pattern int
+	// Start and End are the byte offsets of the match in the searched string. With
+	// insensitive matching they span every rune the match was folded from.
+	Start, End int
+}
+
+// Matcher searches strings for many patterns at once using the Aho-Corasick algorithm, so the
+// search takes time proportional to the length of the searched string and the number of
+// matches, whatever the number of patterns. A Matcher is safe for concurrent use.
+type Matcher struct {
+	patterns []string
+	opts     MatcherOptions
+	// lengths holds the byte lengths of the folded patterns.
+	lengths []int
+	// terminals holds the number of distinct non-empty folded patterns.
+	terminals int
+	// hasEmpty tells whether some pattern is empty once folded.
+	hasEmpty bool
+	root     [256]int32
+	nodes    []acNode
+}
+
+type acNode struct {
+	edges []acEdge
+	fail  int32
+	// dict is the nearest node on the failure chain ending some pattern, or -1.
+	dict int32
+	// patterns holds the indexes of the patterns ending at the node.
+	patterns []int
+}
+
+type acEdge struct {
+	label byte
+	to    int32
+}
+
+// NewMatcher compiles a Matcher searching for given patterns.
+func NewMatcher(patterns []string, opts MatcherOptions) *Matcher {
+	m := &Matcher{
+		patterns: append([]string(nil), patterns...),
+		opts:     opts,
+		lengths:  make([]int, len(patterns)),
+		nodes:    []acNode{{dict: -1}},
+	}
+	for i, p := range patterns {
+		folded, _ := m.fold(p)
+		m.lengths[i] = len(folded)
+		if folded == "" {
+			m.hasEmpty = true
+			continue
+		}
+		node := m.insert(folded)
+		if len(m.nodes[node].patterns) == 0 {
+			m.terminals++
+		}
+		m.nodes[node].patterns = append(m.nodes[node].patterns, i)
+	}
+	m.link()
+	return m
+}
+
+// insert adds the path of given pattern to the trie and returns its last node.
+func (m *Matcher) insert(pattern string) int32 {
+	node := int32(0)
+	for i := 0; i < len(pattern); i++ {
+		next, ok := m.edge(node, pattern[i])
+		if !ok {
+			next = int32(len(m.nodes))
+			m.nodes = append(m.nodes, acNode{dict: -1})
+			if node == 0 {
+				m.root[pattern[i]] = next
+			}
+			edges := m.nodes[node].edges
+			j := sort.Search(len(edges), func(j int) bool { return edges[j].label >= pattern[i] })
+			edges = append(edges, acEdge{})
+			copy(edges[j+1:], edges[j:])
+			edges[j] = acEdge{label: pattern[i], to: next}
+			m.nodes[node].edges = edges
+		}
+		node = next
+	}
+	return node
+}
+
+// link computes the failure and dictionary links breadth first.
+func (m *Matcher) link() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, e := range m.nodes[0].edges {
+		queue = append(queue, e.to)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, e := range m.nodes[node].edges {
+			fail := m.step(m.nodes[node].fail, e.label)
+			m.nodes[e.to].fail = fail
+			if len(m.nodes[fail].patterns) > 0 {
+				m.nodes[e.to].dict = fail
+			} else {
+				m.nodes[e.to].dict = m.nodes[fail].dict
+			}
+			queue = append(queue, e.to)
+		}
+	}
+}
+
+func (m *Matcher) edge(node int32, b byte) (int32, bool) {
+	if node == 0 {
+		next := m.root[b]
+		return next, next != 0
+	}
+	edges := m.nodes[node].edges
+	if len(edges) <= 8 {
+		for _, e := range edges {
+			if e.label == b {
+				return e.to, true
+			}
+		}
+		return 0, false
+	}
+	j := sort.Search(len(edges), func(j int) bool { return edges[j].label >= b })
+	if j < len(edges) && edges[j].label == b {
+		return edges[j].to, true
+	}
+	return 0, false
+}
+
+// step returns the node reached from given node by reading b.
+func (m *Matcher) step(node int32, b byte) int32 {
+	for {
+		if next, ok := m.edge(node, b); ok {
+			return next
+		}
+		if node == 0 {
+			return 0
+		}
+		node = m.nodes[node].fail
+	}
+}
+
+// scan calls yield with every node ending a pattern found in text and the end offset of the
+// occurrence, until yield returns false.
+func (m *Matcher) scan(text string, yield func(node int32, end int) bool) {
+	node := int32(0)
+	for i := 0; i < len(text); i++ {
+		node = m.step(node, text[i])
+		out := node
+		if len(m.nodes[out].patterns) == 0 {
+			out = m.nodes[out].dict
+		}
+		for ; out > 0; out = m.nodes[out].dict {
+			if !yield(out, i+1) {
+				return
+			}
+		}
+	}
+}
+
+// Patterns returns the patterns of the matcher.
+func (m *Matcher) Patterns() []string { return append([]string(nil), m.patterns...) }
+
+// FindAll returns the matches found in given string, ordered by their start offsets, longer
+// matches first. Empty patterns are never reported.
+func (m *Matcher) FindAll(s string) []Match {
+	text, offsets := m.fold(s)
+	var matches []Match
+	m.scan(text, func(node int32, end int) bool {
+		for _, p := range m.nodes[node].patterns {
+			match := Match{Pattern: p, Start: end - m.lengths[p], End: end}
+			if offsets != nil {
+				match.Start, match.End = offsets.ByteRange(match.Start, match.End)
+			}
+			matches = append(matches, match)
+		}
+		return true
+	})
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		if a.End != b.End {
+			return a.End > b.End
+		}
+		return a.Pattern < b.Pattern
+	})
+	if m.opts.Kind == Overlapping {
+		return matches
+	}
+	selected := matches[:0]
+	end := 0
+	for _, match := range matches {
+		if match.Start >= end {
+			selected = append(selected, match)
+			end = match.End
+		}
+	}
+	return selected
+}
+
+// ContainsAny checks if given string contains any of the patterns. Empty patterns are contained
+// in every string.
+func (m *Matcher) ContainsAny(s string) bool {
+	if m.hasEmpty {
+		return true
+	}
+	found := false
+	text, _ := m.fold(s)
+	m.scan(text, func(int32, int) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// ContainsAll checks if given string contains all the patterns.
+func (m *Matcher) ContainsAll(s string) bool {
+	if m.terminals == 0 {
+		return true
+	}
+	found := make(map[int32]bool)
+	text, _ := m.fold(s)
+	m.scan(text, func(node int32, _ int) bool {
+		found[node] = true
+		return len(found) < m.terminals
+	})
+	return len(found) == m.terminals
+}
+
+// fold applies the insensitivity options to s. The offset map is nil when offsets are
+// unchanged.
+func (m *Matcher) fold(s string) (string, *OffsetMap) {
+	if !m.opts.CaseInsensitive && !m.opts.DiacriticInsensitive {
+		return s, nil
+	}
+	if isASCII(s) {
+		if m.opts.CaseInsensitive {
+			return strings.ToLower(s), nil
+		}
+		return s, nil
+	}
+	return mapRunes(s, m.foldRune)
+}
+
+func (m *Matcher) foldRune(r rune) string {
+	s := string(r)
+	if r >= utf8.RuneSelf && m.opts.DiacriticInsensitive {
+		s = norm.NFC.String(removeDiacritics(norm.NFD.String(s)))
+	}
+	if m.opts.CaseInsensitive {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
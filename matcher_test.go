@@ -0,0 +1,112 @@
+package agstring
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcherFindAll(t *testing.T) {
+	patterns := []string{"he", "she", "his", "hers", "her"}
+
+	m := NewMatcher(patterns, MatcherOptions{Kind: Overlapping})
+	require.Equal(t, []Match{
+		{Pattern: 1, Start: 1, End: 4},
+		{Pattern: 3, Start: 2, End: 6},
+		{Pattern: 4, Start: 2, End: 5},
+		{Pattern: 0, Start: 2, End: 4},
+	}, m.FindAll("ushers"))
+
+	m = NewMatcher(patterns, MatcherOptions{})
+	require.Equal(t, []Match{{Pattern: 1, Start: 1, End: 4}}, m.FindAll("ushers"))
+	require.Equal(t, []Match{
+		{Pattern: 3, Start: 0, End: 4},
+		{Pattern: 2, Start: 5, End: 8},
+	}, m.FindAll("hers his"))
+	require.Empty(t, m.FindAll(""))
+	require.Empty(t, m.FindAll("xyz"))
+}
+
+func TestMatcherDuplicatesAndEmpty(t *testing.T) {
+	m := NewMatcher([]string{"ab", "", "ab"}, MatcherOptions{Kind: Overlapping})
+	require.Equal(t, []Match{{Pattern: 0, Start: 1, End: 3}, {Pattern: 2, Start: 1, End: 3}}, m.FindAll("xab"))
+	require.True(t, m.ContainsAny("xyz"))
+	require.True(t, m.ContainsAll("xab"))
+	require.False(t, m.ContainsAll("xyz"))
+
+	m = NewMatcher([]string{"ab", "ab"}, MatcherOptions{})
+	require.Equal(t, []Match{{Pattern: 0, Start: 0, End: 2}}, m.FindAll("ab"))
+	require.False(t, m.ContainsAny("xyz"))
+
+	m = NewMatcher(nil, MatcherOptions{})
+	require.False(t, m.ContainsAny("abc"))
+	require.True(t, m.ContainsAll("abc"))
+	require.Empty(t, m.FindAll("abc"))
+}
+
+func TestMatcherInsensitive(t *testing.T) {
+	patterns := []string{"cafe", "STRASSE", "Łódź", "ß"}
+
+	m := NewMatcher(patterns, MatcherOptions{CaseInsensitive: true, DiacriticInsensitive: true})
+	s := "Le CAFÉ de la Straße à ŁODZ"
+	matches := m.FindAll(s)
+	require.Len(t, matches, 3)
+	require.Equal(t, "CAFÉ", s[matches[0].Start:matches[0].End])
+	require.Equal(t, 0, matches[0].Pattern)
+	require.Equal(t, "ß", s[matches[1].Start:matches[1].End])
+	require.Equal(t, 3, matches[1].Pattern)
+	require.Equal(t, "ŁODZ", s[matches[2].Start:matches[2].End])
+	require.Equal(t, 2, matches[2].Pattern)
+
+	require.True(t, m.ContainsAny("café"))
+	require.True(t, NewMatcher([]string{"ДОМ"}, MatcherOptions{CaseInsensitive: true}).ContainsAny("дом"))
+	require.False(t, NewMatcher([]string{"dom"}, MatcherOptions{DiacriticInsensitive: true}).ContainsAny("дом"))
+	require.False(t, NewMatcher([]string{"cafe"}, MatcherOptions{CaseInsensitive: true}).ContainsAny("café"))
+	require.False(t, NewMatcher([]string{"cafe"}, MatcherOptions{DiacriticInsensitive: true}).ContainsAny("CAFÉ"))
+}
+
+func TestMatcherOverlappingAgainstIndex(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	word := func(n int) string {
+		b := make([]byte, 1+rnd.Intn(n))
+		for i := range b {
+			b[i] = "abc"[rnd.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 200; i++ {
+		patterns := make([]string, 1+rnd.Intn(6))
+		for j := range patterns {
+			patterns[j] = word(4)
+		}
+		text := word(30)
+
+		var expected []Match
+		for start := range text {
+			for end := len(text); end > start; end-- {
+				for p, pattern := range patterns {
+					if text[start:end] == pattern {
+						expected = append(expected, Match{Pattern: p, Start: start, End: end})
+					}
+				}
+			}
+		}
+		m := NewMatcher(patterns, MatcherOptions{Kind: Overlapping})
+		require.Equal(t, expected, m.FindAll(text), "for %q in %q", patterns, text)
+		require.Equal(t, StringContainsAny(text, patterns), m.ContainsAny(text))
+		require.Equal(t, StringContainsAll(text, patterns), m.ContainsAll(text))
+	}
+}
+
+func TestStringContainsWithMatcher(t *testing.T) {
+	blocklist := []string{"spam", "scam", "phish"}
+	m := NewMatcher(blocklist, MatcherOptions{CaseInsensitive: true})
+
+	require.True(t, StringContainsAny("this is SPAM", nil, m))
+	require.False(t, StringContainsAny("this is SPAM", blocklist))
+	require.False(t, StringContainsAny("all good", blocklist, m))
+	require.True(t, StringContainsAll("Spam, scam and phishing", nil, m))
+	require.False(t, StringContainsAll("spam and scam", blocklist, m))
+	require.True(t, StringContainsAll("spam scam phish", blocklist, nil))
+}
@@ -0,0 +1,642 @@
+package agstring
+
+import "strings"
+
+// Metaphone is the original Metaphone algorithm of Lawrence Philips for English words, e.g.
+// "NT" for "Knight".
+type Metaphone struct {
+	// MaxLength limits the length of the codes. Zero means no limit.
+	MaxLength int
+}
+
+// Name implements Encoder.
+func (Metaphone) Name() string { return "metaphone" }
+
+// Encode implements Encoder.
+func (e Metaphone) Encode(s string) string {
+	w := phoneticLetters(s)
+	switch {
+	case len(w) <= 1:
+		return w
+	case strings.HasPrefix(w, "AE"), strings.HasPrefix(w, "GN"), strings.HasPrefix(w, "KN"),
+		strings.HasPrefix(w, "PN"), strings.HasPrefix(w, "WR"):
+		w = w[1:]
+	case strings.HasPrefix(w, "WH"):
+		w = "W" + w[2:]
+	case w[0] == 'X':
+		w = "S" + w[1:]
+	}
+
+	at := func(i int) byte {
+		if i >= 0 && i < len(w) {
+			return w[i]
+		}
+		return 0
+	}
+	isVowel := func(i int) bool { return i >= 0 && i < len(w) && strings.IndexByte("AEIOU", w[i]) >= 0 }
+	isFrontVowel := func(i int) bool { return at(i) == 'E' || at(i) == 'I' || at(i) == 'Y' }
+	has := func(i int, sub string) bool { return strings.HasPrefix(w[i:], sub) }
+
+	var code strings.Builder
+	for i := 0; i < len(w) && (e.MaxLength <= 0 || code.Len() < e.MaxLength); i++ {
+		c := w[i]
+		if c != 'C' && at(i-1) == c {
+			continue
+		}
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code.WriteByte(c)
+			}
+		case 'B':
+			if !(at(i-1) == 'M' && i == len(w)-1) {
+				code.WriteByte('B')
+			}
+		case 'C':
+			switch {
+			case at(i-1) == 'S' && isFrontVowel(i+1):
+				// silent in SCI, SCE and SCY
+			case has(i, "CIA"):
+				code.WriteByte('X')
+			case isFrontVowel(i + 1):
+				code.WriteByte('S')
+			case at(i-1) == 'S' && at(i+1) == 'H':
+				code.WriteByte('K')
+			case at(i+1) == 'H' && i == 0 && !isVowel(i+2):
+				// e.g. "Christ"
+				code.WriteByte('K')
+			case at(i+1) == 'H':
+				code.WriteByte('X')
+			default:
+				code.WriteByte('K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && isFrontVowel(i+2) {
+				code.WriteByte('J')
+				i += 2
+			} else {
+				code.WriteByte('T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < len(w) && !isVowel(i+2), at(i+1) == 'H' && i+2 == len(w):
+				// silent GH, e.g. "night", but not "ghost"
+			case i > 0 && (w[i:] == "GN" || w[i:] == "GNED"):
+				// silent G, e.g. "sign"
+			case isFrontVowel(i + 1):
+				code.WriteByte('J')
+			default:
+				code.WriteByte('K')
+			}
+		case 'H':
+			if i < len(w)-1 && strings.IndexByte("CSPTG", at(i-1)) < 0 && isVowel(i+1) {
+				code.WriteByte('H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				code.WriteByte('K')
+			}
+		case 'P':
+			code.WriteByte(choose(at(i+1) == 'H', 'F', 'P'))
+		case 'Q':
+			code.WriteByte('K')
+		case 'S':
+			code.WriteByte(choose(has(i, "SH") || has(i, "SIO") || has(i, "SIA"), 'X', 'S'))
+		case 'T':
+			switch {
+			case has(i, "TIA"), has(i, "TIO"):
+				code.WriteByte('X')
+			case has(i, "TCH"):
+				// silent
+			case has(i, "TH"):
+				code.WriteByte('0')
+			default:
+				code.WriteByte('T')
+			}
+		case 'V':
+			code.WriteByte('F')
+		case 'W', 'Y':
+			if isVowel(i + 1) {
+				code.WriteByte(c)
+			}
+		case 'X':
+			code.WriteString("KS")
+		case 'Z':
+			code.WriteByte('S')
+		case 'F', 'J', 'L', 'M', 'N', 'R':
+			code.WriteByte(c)
+		}
+	}
+	return limitLength(code.String(), e.MaxLength)
+}
+
+// DoubleMetaphone is the Double Metaphone algorithm of Lawrence Philips, which accounts for
+// the spelling of names of many origins. Besides the primary code, which Encode returns, it
+// builds an alternate code for words with a second common pronunciation, e.g. "XMT" and "SMT"
+// for "Schmidt".
+type DoubleMetaphone struct {
+	// MaxLength limits the length of the codes, 4 in the original algorithm. Zero means no
+	// limit.
+	MaxLength int
+}
+
+// Name implements Encoder.
+func (DoubleMetaphone) Name() string { return "double_metaphone" }
+
+// Encode implements Encoder by returning the primary code.
+func (e DoubleMetaphone) Encode(s string) string {
+	primary, _ := e.EncodeBoth(s)
+	return primary
+}
+
+// EncodeBoth returns the primary and alternate codes of given string. Both are equal when the
+// string has a single pronunciation.
+func (e DoubleMetaphone) EncodeBoth(s string) (primary, alternate string) {
+	text := phoneticText(s)
+	if text == "" {
+		return "", ""
+	}
+	d := &doubleMetaphone{
+		s:      text + "     ",
+		length: len(text),
+		last:   len(text) - 1,
+		slavoGermanic: strings.ContainsAny(text, "WK") || strings.Contains(text, "CZ") ||
+			strings.Contains(text, "WITZ"),
+	}
+	d.encode(e.MaxLength)
+	return limitLength(d.primary.String(), e.MaxLength), limitLength(d.alternate.String(), e.MaxLength)
+}
+
+type doubleMetaphone struct {
+	// s is the encoded text padded with spaces
+	s                  string
+	length, last       int
+	slavoGermanic      bool
+	primary, alternate strings.Builder
+}
+
+func (d *doubleMetaphone) at(i int) byte {
+	if i < 0 || i >= len(d.s) {
+		return 0
+	}
+	return d.s[i]
+}
+
+// stringAt checks if any of given strings of length n starts at i.
+func (d *doubleMetaphone) stringAt(i, n int, ss ...string) bool {
+	if i < 0 || i+n > len(d.s) {
+		return false
+	}
+	sub := d.s[i : i+n]
+	for _, s := range ss {
+		if sub == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *doubleMetaphone) isVowel(i int) bool {
+	return i >= 0 && i < d.length && strings.IndexByte("AEIOUY", d.s[i]) >= 0
+}
+
+// add appends the main code to both codes, or to the primary code only when an alternate is
+// given.
+func (d *doubleMetaphone) add(main string, alternate ...string) {
+	d.primary.WriteString(main)
+	if len(alternate) > 0 {
+		d.alternate.WriteString(alternate[0])
+	} else {
+		d.alternate.WriteString(main)
+	}
+}
+
+func (d *doubleMetaphone) encode(maxLength int) {
+	cur := 0
+	if d.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		cur++
+	}
+	// initial X is pronounced Z, e.g. "Xavier"
+	if d.at(0) == 'X' {
+		d.add("S")
+		cur++
+	}
+	for cur < d.length && (maxLength <= 0 || d.primary.Len() < maxLength || d.alternate.Len() < maxLength) {
+		switch d.at(cur) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if cur == 0 {
+				d.add("A")
+			}
+			cur++
+		case 'B':
+			d.add("P")
+			cur += d.skipDouble(cur, "B")
+		case 'C':
+			cur = d.encodeC(cur)
+		case 'D':
+			switch {
+			case d.stringAt(cur, 2, "DG") && d.stringAt(cur+2, 1, "I", "E", "Y"):
+				// e.g. "edge"
+				d.add("J")
+				cur += 3
+			case d.stringAt(cur, 2, "DG"):
+				// e.g. "edgar"
+				d.add("TK")
+				cur += 2
+			case d.stringAt(cur, 2, "DT", "DD"):
+				d.add("T")
+				cur += 2
+			default:
+				d.add("T")
+				cur++
+			}
+		case 'F':
+			d.add("F")
+			cur += d.skipDouble(cur, "F")
+		case 'G':
+			cur = d.encodeG(cur)
+		case 'H':
+			// only kept when first or between vowels
+			if (cur == 0 || d.isVowel(cur-1)) && d.isVowel(cur+1) {
+				d.add("H")
+				cur++
+			}
+			cur++
+		case 'J':
+			cur = d.encodeJ(cur)
+		case 'K':
+			d.add("K")
+			cur += d.skipDouble(cur, "K")
+		case 'L':
+			if d.at(cur+1) == 'L' {
+				// Spanish, e.g. "cabrillo", "gallegos"
+				if cur == d.length-3 && d.stringAt(cur-1, 4, "ILLO", "ILLA", "ALLE") ||
+					(d.stringAt(d.last-1, 2, "AS", "OS") || d.stringAt(d.last, 1, "A", "O")) &&
+						d.stringAt(cur-1, 4, "ALLE") {
+					d.add("L", "")
+					cur += 2
+					continue
+				}
+				cur++
+			}
+			d.add("L")
+			cur++
+		case 'M':
+			if d.stringAt(cur-1, 3, "UMB") && (cur+1 == d.last || d.stringAt(cur+2, 2, "ER")) ||
+				d.at(cur+1) == 'M' {
+				cur++
+			}
+			d.add("M")
+			cur++
+		case 'N':
+			d.add("N")
+			cur += d.skipDouble(cur, "N")
+		case 'P':
+			if d.at(cur+1) == 'H' {
+				d.add("F")
+				cur += 2
+				continue
+			}
+			// e.g. "campbell", "raspberry"
+			d.add("P")
+			cur += d.skipDouble(cur, "P", "B")
+		case 'Q':
+			d.add("K")
+			cur += d.skipDouble(cur, "Q")
+		case 'R':
+			// French, e.g. "rogier", but not "hochmeier"
+			if cur == d.last && !d.slavoGermanic && d.stringAt(cur-2, 2, "IE") &&
+				!d.stringAt(cur-4, 2, "ME", "MA") {
+				d.add("", "R")
+			} else {
+				d.add("R")
+			}
+			cur += d.skipDouble(cur, "R")
+		case 'S':
+			cur = d.encodeS(cur)
+		case 'T':
+			cur = d.encodeT(cur)
+		case 'V':
+			d.add("F")
+			cur += d.skipDouble(cur, "V")
+		case 'W':
+			cur = d.encodeW(cur)
+		case 'X':
+			// French, e.g. "breaux"
+			if !(cur == d.last && (d.stringAt(cur-3, 3, "IAU", "EAU") || d.stringAt(cur-2, 2, "AU", "OU"))) {
+				d.add("KS")
+			}
+			cur += d.skipDouble(cur, "C", "X")
+		case 'Z':
+			switch {
+			case d.at(cur+1) == 'H':
+				// Chinese pinyin, e.g. "zhao"
+				d.add("J")
+				cur += 2
+				continue
+			case d.stringAt(cur+1, 2, "ZO", "ZI", "ZA") || d.slavoGermanic && cur > 0 && d.at(cur-1) != 'T':
+				d.add("S", "TS")
+			default:
+				d.add("S")
+			}
+			cur += d.skipDouble(cur, "Z")
+		default:
+			cur++
+		}
+	}
+}
+
+// skipDouble returns 2 when the letter after i is one of given letters and 1 otherwise.
+func (d *doubleMetaphone) skipDouble(i int, letters ...string) int {
+	if d.stringAt(i+1, 1, letters...) {
+		return 2
+	}
+	return 1
+}
+
+func (d *doubleMetaphone) encodeC(cur int) int {
+	switch {
+	case cur > 1 && !d.isVowel(cur-2) && d.stringAt(cur-1, 3, "ACH") && d.at(cur+2) != 'I' &&
+		(d.at(cur+2) != 'E' || d.stringAt(cur-2, 6, "BACHER", "MACHER")):
+		// Germanic, e.g. "bacher"
+		d.add("K")
+		return cur + 2
+	case cur == 0 && d.stringAt(cur, 6, "CAESAR"):
+		d.add("S")
+		return cur + 2
+	case d.stringAt(cur, 4, "CHIA"):
+		// Italian, e.g. "chianti"
+		d.add("K")
+		return cur + 2
+	case d.stringAt(cur, 2, "CH"):
+		return d.encodeCH(cur)
+	case d.stringAt(cur, 2, "CZ") && !d.stringAt(cur-2, 4, "WICZ"):
+		// e.g. "czerny"
+		d.add("S", "X")
+		return cur + 2
+	case d.stringAt(cur+1, 3, "CIA"):
+		// e.g. "focaccia"
+		d.add("X")
+		return cur + 3
+	case d.stringAt(cur, 2, "CC") && !(cur == 1 && d.at(0) == 'M'):
+		// double C, but not in e.g. "McClellan"
+		if d.stringAt(cur+2, 1, "I", "E", "H") && !d.stringAt(cur+2, 2, "HU") {
+			if cur == 1 && d.at(0) == 'A' || d.stringAt(cur-1, 5, "UCCEE", "UCCES") {
+				// e.g. "accident", "succeed"
+				d.add("KS")
+			} else {
+				// Italian, e.g. "bacci", "bertucci"
+				d.add("X")
+			}
+			return cur + 3
+		}
+		d.add("K")
+		return cur + 2
+	case d.stringAt(cur, 2, "CK", "CG", "CQ"):
+		d.add("K")
+		return cur + 2
+	case d.stringAt(cur, 2, "CI", "CE", "CY"):
+		if d.stringAt(cur, 3, "CIO", "CIE", "CIA") {
+			d.add("S", "X")
+		} else {
+			d.add("S")
+		}
+		return cur + 2
+	}
+	d.add("K")
+	switch {
+	case d.stringAt(cur+1, 2, " C", " Q", " G"):
+		// e.g. "mac caffrey", "mac gregor"
+		return cur + 3
+	case d.stringAt(cur+1, 1, "C", "K", "Q") && !d.stringAt(cur+1, 2, "CE", "CI"):
+		return cur + 2
+	}
+	return cur + 1
+}
+
+func (d *doubleMetaphone) encodeCH(cur int) int {
+	switch {
+	case cur > 0 && d.stringAt(cur, 4, "CHAE"):
+		// e.g. "michael"
+		d.add("K", "X")
+	case cur == 0 && (d.stringAt(cur+1, 5, "HARAC", "HARIS") || d.stringAt(cur+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+		!d.stringAt(0, 5, "CHORE"):
+		// Greek roots, e.g. "chemistry", "chorus"
+		d.add("K")
+	case d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") ||
+		d.stringAt(cur-2, 6, "ORCHES", "ARCHIT", "ORCHID") || d.stringAt(cur+2, 1, "T", "S") ||
+		(d.stringAt(cur-1, 1, "A", "O", "U", "E") || cur == 0) &&
+			d.stringAt(cur+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " "):
+		// Germanic and Greek, e.g. "wachtler", "architect", "orchestra"
+		d.add("K")
+	case cur > 0 && d.stringAt(0, 2, "MC"):
+		// e.g. "McHugh"
+		d.add("K")
+	case cur > 0:
+		d.add("X", "K")
+	default:
+		d.add("X")
+	}
+	return cur + 2
+}
+
+func (d *doubleMetaphone) encodeG(cur int) int {
+	next := d.at(cur + 1)
+	switch {
+	case next == 'H':
+		return d.encodeGH(cur)
+	case next == 'N':
+		switch {
+		case cur == 1 && d.isVowel(0) && !d.slavoGermanic:
+			d.add("KN", "N")
+		case !d.stringAt(cur+2, 2, "EY") && !d.slavoGermanic:
+			// not e.g. "cagney"
+			d.add("N", "KN")
+		default:
+			d.add("KN")
+		}
+		return cur + 2
+	case d.stringAt(cur+1, 2, "LI") && !d.slavoGermanic:
+		// e.g. "tagliaro"
+		d.add("KL", "L")
+		return cur + 2
+	case cur == 0 && (next == 'Y' ||
+		d.stringAt(cur+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		d.add("K", "J")
+		return cur + 2
+	case (d.stringAt(cur+1, 2, "ER") || next == 'Y') && !d.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!d.stringAt(cur-1, 1, "E", "I") && !d.stringAt(cur-1, 3, "RGY", "OGY"):
+		// -ger-, -gy-
+		d.add("K", "J")
+		return cur + 2
+	case d.stringAt(cur+1, 1, "E", "I", "Y") || d.stringAt(cur-1, 4, "AGGI", "OGGI"):
+		// Italian, e.g. "biaggi"
+		switch {
+		case d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") || d.stringAt(cur+1, 2, "ET"):
+			d.add("K")
+		case d.stringAt(cur+1, 4, "IER "):
+			// French ending
+			d.add("J")
+		default:
+			d.add("J", "K")
+		}
+		return cur + 2
+	}
+	d.add("K")
+	return cur + d.skipDouble(cur, "G")
+}
+
+func (d *doubleMetaphone) encodeGH(cur int) int {
+	switch {
+	case cur > 0 && !d.isVowel(cur-1):
+		d.add("K")
+	case cur == 0:
+		// e.g. "ghislane"
+		if d.at(cur+2) == 'I' {
+			d.add("J")
+		} else {
+			d.add("K")
+		}
+	case cur > 1 && d.stringAt(cur-2, 1, "B", "H", "D") || cur > 2 && d.stringAt(cur-3, 1, "B", "H", "D") ||
+		cur > 3 && d.stringAt(cur-4, 1, "B", "H"):
+		// Parker's rule, e.g. "hugh", "bough", "broughton"
+	case cur > 2 && d.at(cur-1) == 'U' && d.stringAt(cur-3, 1, "C", "G", "L", "R", "T"):
+		// e.g. "laugh", "cough", "rough"
+		d.add("F")
+	case d.at(cur-1) != 'I':
+		d.add("K")
+	}
+	return cur + 2
+}
+
+func (d *doubleMetaphone) encodeJ(cur int) int {
+	if d.stringAt(cur, 4, "JOSE") || d.stringAt(0, 4, "SAN ") {
+		// Spanish, e.g. "jose", "san jacinto"
+		if cur == 0 && d.at(cur+4) == ' ' || d.stringAt(0, 4, "SAN ") {
+			d.add("H")
+		} else {
+			d.add("J", "H")
+		}
+		return cur + 1
+	}
+	switch {
+	case cur == 0:
+		// e.g. "Yankelovich" and "Jankelowicz"
+		d.add("J", "A")
+	case d.isVowel(cur-1) && !d.slavoGermanic && (d.at(cur+1) == 'A' || d.at(cur+1) == 'O'):
+		// Spanish, e.g. "bajador"
+		d.add("J", "H")
+	case cur == d.last:
+		d.add("J", "")
+	case !d.stringAt(cur+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !d.stringAt(cur-1, 1, "S", "K", "L"):
+		d.add("J")
+	}
+	return cur + d.skipDouble(cur, "J")
+}
+
+func (d *doubleMetaphone) encodeS(cur int) int {
+	switch {
+	case d.stringAt(cur-1, 3, "ISL", "YSL"):
+		// silent, e.g. "island", "carlisle"
+		return cur + 1
+	case cur == 0 && d.stringAt(cur, 5, "SUGAR"):
+		d.add("X", "S")
+		return cur + 1
+	case d.stringAt(cur, 2, "SH"):
+		if d.stringAt(cur+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			d.add("S")
+		} else {
+			d.add("X")
+		}
+		return cur + 2
+	case d.stringAt(cur, 3, "SIO", "SIA") || d.stringAt(cur, 4, "SIAN"):
+		// Italian and Armenian
+		if d.slavoGermanic {
+			d.add("S")
+		} else {
+			d.add("S", "X")
+		}
+		return cur + 3
+	case cur == 0 && d.stringAt(cur+1, 1, "M", "N", "L", "W") || d.stringAt(cur+1, 1, "Z"):
+		// German and anglicisations, e.g. "smith" and "schmidt", "snider" and "schneider"
+		d.add("S", "X")
+		return cur + d.skipDouble(cur, "Z")
+	case d.stringAt(cur, 2, "SC"):
+		switch {
+		case d.at(cur+2) == 'H' && d.stringAt(cur+3, 2, "OO", "ER", "EN", "UY", "ED", "EM"):
+			// Dutch, e.g. "school", "schooner", "schermerhorn"
+			if d.stringAt(cur+3, 2, "ER", "EN") {
+				d.add("X", "SK")
+			} else {
+				d.add("SK")
+			}
+		case d.at(cur+2) == 'H':
+			// Schlesinger's rule
+			if cur == 0 && !d.isVowel(3) && d.at(3) != 'W' {
+				d.add("X", "S")
+			} else {
+				d.add("X")
+			}
+		case d.stringAt(cur+2, 1, "I", "E", "Y"):
+			d.add("S")
+		default:
+			d.add("SK")
+		}
+		return cur + 3
+	case cur == d.last && d.stringAt(cur-2, 2, "AI", "OI"):
+		// French, e.g. "resnais", "artois"
+		d.add("", "S")
+	default:
+		d.add("S")
+	}
+	return cur + d.skipDouble(cur, "S", "Z")
+}
+
+func (d *doubleMetaphone) encodeT(cur int) int {
+	switch {
+	case d.stringAt(cur, 4, "TION"):
+		d.add("X")
+		return cur + 3
+	case d.stringAt(cur, 3, "TIA", "TCH"):
+		d.add("X")
+		return cur + 3
+	case d.stringAt(cur, 2, "TH") || d.stringAt(cur, 3, "TTH"):
+		if d.stringAt(cur+2, 2, "OM", "AM") || d.stringAt(0, 4, "VAN ", "VON ") || d.stringAt(0, 3, "SCH") {
+			// e.g. "thomas", "thames" or Germanic
+			d.add("T")
+		} else {
+			d.add("0", "T")
+		}
+		return cur + 2
+	}
+	d.add("T")
+	return cur + d.skipDouble(cur, "T", "D")
+}
+
+func (d *doubleMetaphone) encodeW(cur int) int {
+	if d.stringAt(cur, 2, "WR") {
+		d.add("R")
+		return cur + 2
+	}
+	if cur == 0 && (d.isVowel(cur+1) || d.stringAt(cur, 2, "WH")) {
+		if d.isVowel(cur + 1) {
+			// e.g. "Wasserman" and "Vasserman"
+			d.add("A", "F")
+		} else {
+			d.add("A")
+		}
+	}
+	switch {
+	case cur == d.last && d.isVowel(cur-1) || d.stringAt(cur-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		d.stringAt(0, 3, "SCH"):
+		// e.g. "Arnow" and "Arnoff"
+		d.add("", "F")
+		return cur + 1
+	case d.stringAt(cur, 4, "WICZ", "WITZ"):
+		// Polish, e.g. "filipowicz"
+		d.add("TS", "FX")
+		return cur + 4
+	}
+	return cur + 1
+}
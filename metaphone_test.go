@@ -0,0 +1,42 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	testCases := []struct {
+		input              string
+		primary, alternate string
+	}{
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Jose", "HS", "HS"},
+		{"Xavier", "SF", "SFR"},
+		{"Arnow", "ARN", "ARNF"},
+		{"Catherine", "K0RN", "KTRN"},
+		{"Michael", "MKL", "MXL"},
+		{"Wikipedia", "AKPT", "FKPT"},
+		{"Gallegos", "KLKS", "KKS"},
+		{"Cabrillo", "KPRL", "KPR"},
+		{"Ghislane", "JLN", "JLN"},
+		{"Caesar", "SSR", "SSR"},
+		{"Bacchus", "PKS", "PKS"},
+		{"Accident", "AKST", "AKST"},
+		{"Chemistry", "KMST", "KMST"},
+		{"Laugh", "LF", "LF"},
+		{"Edge", "AJ", "AJ"},
+		{"Knight", "NT", "NT"},
+		{"Van Dyke", "FNTK", "FNTK"},
+	}
+
+	e := DoubleMetaphone{MaxLength: 4}
+	for _, testCase := range testCases {
+		primary, alternate := e.EncodeBoth(testCase.input)
+		require.Equal(t, testCase.primary, primary, "primary of %q", testCase.input)
+		require.Equal(t, testCase.alternate, alternate, "alternate of %q", testCase.input)
+		require.Equal(t, primary, e.Encode(testCase.input))
+	}
+}
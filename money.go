@@ -0,0 +1,185 @@
+package agstring
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MoneyOptions configures ExtractMoney.
+type MoneyOptions struct {
+	// Lang is the locale amounts are written in, see SafeParseDecimal. It may be empty to use
+	// the locale of the currency, such as Indian grouping for "₹12,34,567", or else guess the
+	// separators.
+	Lang string
+	// Defaults maps ambiguous currency symbols to the ISO 4217 codes they stand for, e.g. "$"
+	// to "CAD". Symbols missing from it stand for their most common currency, e.g. "USD" for
+	// "$", "JPY" for "¥" and "SEK" for "kr".
+	Defaults map[string]string
+	// AllCodes matches every ISO 4217 code rather than only the common ones, such as "USD" or
+	// "EUR". Many codes are also words, as in "TOP 10" or "ALL 5".
+	AllCodes bool
+}
+
+// Money is an amount of money found in a string.
+type Money struct {
+	Amount Decimal
+	// Currency is the ISO 4217 code of the currency, e.g. "EUR".
+	Currency string
+	// Start and End are the byte offsets of the amount and its currency in the searched string.
+	Start, End int
+}
+
+// currencyCodes holds the active ISO 4217 currency codes.
+var currencyCodes = strings.Fields(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP
+	BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP
+	GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR
+	KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK
+	MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR
+	SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS
+	UAH UGX USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+
+// commonCurrencyCodes holds the ISO 4217 codes commonly written with amounts, matched without
+// MoneyOptions.AllCodes.
+var commonCurrencyCodes = strings.Fields(`
+	USD EUR GBP JPY CHF CAD AUD NZD CNY HKD SGD TWD KRW INR PKR SEK NOK DKK ISK PLN CZK HUF RON
+	BGN TRY RUB UAH ILS AED SAR EGP ZAR NGN KES BRL MXN ARS CLP COP THB IDR MYR PHP VND`)
+
+// currencySymbols maps currency symbols to the codes of the currencies they may stand for,
+// the most common first.
+var currencySymbols = map[string][]string{
+	"$":   {"USD", "CAD", "AUD", "NZD", "MXN", "SGD", "HKD", "ARS", "CLP", "COP"},
+	"US$": {"USD"}, "C$": {"CAD"}, "CA$": {"CAD"}, "A$": {"AUD"}, "AU$": {"AUD"},
+	"NZ$": {"NZD"}, "HK$": {"HKD"}, "S$": {"SGD"}, "R$": {"BRL"}, "MX$": {"MXN"},
+	"NT$": {"TWD"}, "€": {"EUR"}, "£": {"GBP", "EGP"}, "¥": {"JPY", "CNY"}, "JP¥": {"JPY"},
+	"CN¥": {"CNY"}, "元": {"CNY"}, "RMB": {"CNY"}, "₺": {"TRY"}, "TL": {"TRY"}, "₹": {"INR"},
+	"Rs": {"INR", "PKR", "LKR", "NPR"}, "Rs.": {"INR", "PKR", "LKR", "NPR"}, "₽": {"RUB"},
+	"₩": {"KRW"}, "₪": {"ILS"}, "₫": {"VND"}, "₱": {"PHP"}, "฿": {"THB"}, "₴": {"UAH"},
+	"₦": {"NGN"}, "₸": {"KZT"}, "₾": {"GEL"}, "₼": {"AZN"}, "zł": {"PLN"},
+	"kr": {"SEK", "NOK", "DKK", "ISK"}, "kr.": {"DKK", "SEK", "NOK", "ISK"}, "Kč": {"CZK"},
+	"Ft": {"HUF"}, "lei": {"RON"}, "Fr.": {"CHF"},
+}
+
+// currencyLangs maps currency symbols to the locales their amounts are written in, when they
+// differ from the guessed ones.
+var currencyLangs = map[string]string{"₹": "en-IN", "Rs": "en-IN", "Rs.": "en-IN", "INR": "en-IN"}
+
+// moneyRegexp matches amounts with the common currency codes, allMoneyRegexp with all of them.
+var moneyRegexp, allMoneyRegexp *regexp.Regexp
+
+func init() {
+	moneyRegexp = compileMoneyRegexp(commonCurrencyCodes)
+	allMoneyRegexp = compileMoneyRegexp(currencyCodes)
+}
+
+func compileMoneyRegexp(codes []string) *regexp.Regexp {
+	currencies := append([]string(nil), codes...)
+	for symbol := range currencySymbols {
+		currencies = append(currencies, symbol)
+	}
+	currency := "(" + regexpAlternation(currencies) + ")"
+	// amounts grouped with spaces, or with other separators
+	amount := `([-−]?(?:\p{Nd}{1,3}(?:[ \x{a0}\x{202f}'’]\p{Nd}{3})+(?:[.,]\p{Nd}+)?|` +
+		`\p{Nd}+(?:[.,'’]\p{Nd}+)*)(?:\s?(?:k|K|mn|bn|M|m|B|million|billion|thousand)\b)?)`
+	return regexp.MustCompile(currency + `\s?` + amount + `|` + amount + `\s?` + currency)
+}
+
+// ExtractMoney returns the amounts of money found in given string with their currencies, such
+// as "$1,299.00", "1.299 €", "EUR 12", "12,50 TL" or "¥3000". Currencies are written with
+// their ISO 4217 codes or their symbols, before or after the amounts, see
+// MoneyOptions.AllCodes. Amounts are parsed like SafeParseDecimal does, and may be negative
+// with a minus sign or accounting parentheses, as in "($42)".
+func ExtractMoney(s string, opts MoneyOptions) []Money {
+	re := moneyRegexp
+	if opts.AllCodes {
+		re = allMoneyRegexp
+	}
+	var found []Money
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		var symbol, amount string
+		if m[2] >= 0 {
+			symbol, amount = s[m[2]:m[3]], s[m[4]:m[5]]
+		} else {
+			amount, symbol = s[m[6]:m[7]], s[m[8]:m[9]]
+		}
+		start, end := m[0], m[1]
+		if !isMoneyBoundary(s, start, end) {
+			continue
+		}
+		value, err := parseMoneyAmount(amount, symbol, opts)
+		if err != nil {
+			continue
+		}
+		if m[2] >= 0 && isMoneyMinus(s, start) {
+			_, size := utf8.DecodeLastRuneInString(s[:start])
+			start -= size
+			value.Unscaled.Neg(value.Unscaled)
+		}
+		if start > 0 && s[start-1] == '(' && end < len(s) && s[end] == ')' {
+			start, end = start-1, end+1
+			value.Unscaled.Neg(value.Unscaled)
+		}
+		found = append(found, Money{Amount: value, Currency: currencyFor(symbol, opts), Start: start, End: end})
+	}
+	return found
+}
+
+// parseMoneyAmount parses the amount of given currency code or symbol.
+func parseMoneyAmount(amount, symbol string, opts MoneyOptions) (Decimal, error) {
+	if opts.Lang != "" {
+		return SafeParseDecimal(amount, opts.Lang)
+	}
+	if lang, ok := currencyLangs[symbol]; ok {
+		if value, err := SafeParseDecimal(amount, lang); err == nil {
+			return value, nil
+		}
+	}
+	return SafeParseDecimal(amount, guessMoneyLang(amount))
+}
+
+var singleGroupRegexp = regexp.MustCompile(`^[-−]?\p{Nd}{1,3}([.,])\p{Nd}{3}\b`)
+
+// guessMoneyLang returns the locale of amounts such as "1.299", with a single separator
+// followed by three digits, which group thousands as amounts rarely have three decimals.
+func guessMoneyLang(amount string) string {
+	switch m := singleGroupRegexp.FindStringSubmatch(amount); {
+	case m == nil || strings.ContainsAny(amount[len(m[0]):], ".,"):
+		return ""
+	case m[1] == ".":
+		return "de"
+	default:
+		return "en"
+	}
+}
+
+// isMoneyMinus checks if a minus sign precedes the currency symbol of s starting at start, as
+// in "-£5". The sign must start a word, so the hyphen of the range "$10-$20" isn't one.
+func isMoneyMinus(s string, start int) bool {
+	r, size := utf8.DecodeLastRuneInString(s[:start])
+	if r != '-' && r != '−' {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:start-size])
+	return start == size || unicode.IsSpace(before) || unicode.Is(unicode.Ps, before)
+}
+
+// isMoneyBoundary checks if the match of s from start to end isn't part of a longer word.
+func isMoneyBoundary(s string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	after, _ := utf8.DecodeRuneInString(s[end:])
+	return !(start > 0 && (unicode.IsLetter(before) || unicode.IsDigit(before))) &&
+		!(end < len(s) && (unicode.IsLetter(after) || unicode.IsDigit(after)))
+}
+
+// currencyFor returns the code of the currency of given code or symbol.
+func currencyFor(symbol string, opts MoneyOptions) string {
+	if code, ok := opts.Defaults[symbol]; ok {
+		return code
+	}
+	if codes, ok := currencySymbols[symbol]; ok {
+		return codes[0]
+	}
+	return symbol
+}
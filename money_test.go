@@ -0,0 +1,62 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMoney(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     MoneyOptions
+		amount   string
+		currency string
+		text     string
+	}{
+		{"now $1,299.00 only", MoneyOptions{}, "1299.00", "USD", "$1,299.00"},
+		{"1.299 € inkl.", MoneyOptions{}, "1299", "EUR", "1.299 €"},
+		{"EUR 12", MoneyOptions{}, "12", "EUR", "EUR 12"},
+		{"12,50 TL", MoneyOptions{}, "12.50", "TRY", "12,50 TL"},
+		{"¥3000", MoneyOptions{}, "3000", "JPY", "¥3000"},
+		{"¥3000", MoneyOptions{Defaults: map[string]string{"¥": "CNY"}}, "3000", "CNY", "¥3000"},
+		{"C$20", MoneyOptions{Defaults: map[string]string{"$": "AUD"}}, "20", "CAD", "C$20"},
+		{"raised $3.5M", MoneyOptions{}, "3500000", "USD", "$3.5M"},
+		{"loss of ($42)", MoneyOptions{}, "-42", "USD", "($42)"},
+		{"-£5", MoneyOptions{}, "-5", "GBP", "-£5"},
+		{"net: -£5", MoneyOptions{}, "-5", "GBP", "-£5"},
+		{"loss [-£5]", MoneyOptions{}, "-5", "GBP", "-£5"},
+		{"1 234,56 zł", MoneyOptions{Lang: "pl"}, "1234.56", "PLN", "1 234,56 zł"},
+		{"1.299,90 €", MoneyOptions{Lang: "de"}, "1299.90", "EUR", "1.299,90 €"},
+		{"R$ 10,00", MoneyOptions{Lang: "pt"}, "10.00", "BRL", "R$ 10,00"},
+		{"٣٤ USD", MoneyOptions{}, "34", "USD", "٣٤ USD"},
+		{"₹12,34,567", MoneyOptions{}, "1234567", "INR", "₹12,34,567"},
+		{"Rs. 1,234.50", MoneyOptions{}, "1234.50", "INR", "Rs. 1,234.50"},
+		{"₹1,234,567", MoneyOptions{}, "1234567", "INR", "₹1,234,567"},
+		{"TOP 10", MoneyOptions{AllCodes: true}, "10", "TOP", "TOP 10"},
+	}
+
+	for _, testCase := range testCases {
+		found := ExtractMoney(testCase.input, testCase.opts)
+		require.Len(t, found, 1, "for %q", testCase.input)
+		require.Equal(t, testCase.amount, found[0].Amount.String(), "for %q", testCase.input)
+		require.Equal(t, testCase.currency, found[0].Currency, "for %q", testCase.input)
+		require.Equal(t, testCase.text, testCase.input[found[0].Start:found[0].End], "for %q", testCase.input)
+	}
+
+	found := ExtractMoney("from €5 to 10 EUR, not 5 EURO or 12 apples", MoneyOptions{})
+	require.Len(t, found, 2)
+	require.Equal(t, "€5", "from €5 to 10 EUR"[found[0].Start:found[0].End])
+	require.Equal(t, "10", found[1].Amount.String())
+	require.Empty(t, ExtractMoney("ABC123 and 12kr3", MoneyOptions{}))
+
+	found = ExtractMoney("$10-$20", MoneyOptions{})
+	require.Len(t, found, 2)
+	require.Equal(t, "10", found[0].Amount.String())
+	require.Equal(t, "20", found[1].Amount.String())
+	require.Equal(t, "$20", "$10-$20"[found[1].Start:found[1].End])
+
+	for _, input := range []string{"TOP 10 songs", "ALL 5 items", "CUP 2", "2 MAD men"} {
+		require.Empty(t, ExtractMoney(input, MoneyOptions{}), "for %q", input)
+	}
+}
@@ -0,0 +1,332 @@
+package agstring
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// Kinds of NumberError, to be compared with errors.Cause.
+var (
+	ErrEmptyNumber     = errors.New("empty number")
+	ErrMalformedNumber = errors.New("malformed number")
+	ErrNumberOverflow  = errors.New("number out of range")
+)
+
+// NumberError reports a number which can't be parsed. Err is one of ErrEmptyNumber,
+// ErrMalformedNumber and ErrNumberOverflow.
+type NumberError struct {
+	Input string
+	Err   error
+}
+
+func (e *NumberError) Error() string {
+	return fmt.Sprintf("%s: %q", e.Err, e.Input)
+}
+
+// Cause returns the kind of the error.
+func (e *NumberError) Cause() error { return e.Err }
+
+// Unwrap returns the kind of the error.
+func (e *NumberError) Unwrap() error { return e.Err }
+
+// Decimal is an exact decimal number, Unscaled × 10^-Scale.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (d Decimal) String() string {
+	if d.Unscaled == nil {
+		return "0"
+	}
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.Scale)
+	}
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+}
+
+// Float64 returns the nearest float64 to the decimal, infinite if it is too large.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+type numberFormat struct {
+	decimal rune
+	// groups holds the group separators besides the spaces and apostrophes all locales accept.
+	groups string
+	// indian groups digits by two but the last three, e.g. "12,34,567".
+	indian bool
+}
+
+var (
+	dotDecimal   = numberFormat{decimal: '.', groups: ","}
+	commaDecimal = numberFormat{decimal: ',', groups: "."}
+	spaceGroups  = numberFormat{decimal: ','}
+	arabicDigits = numberFormat{decimal: '٫', groups: "٬,"}
+)
+
+var numberFormats = map[string]numberFormat{
+	"en": dotDecimal, "ja": dotDecimal, "zh": dotDecimal, "ko": dotDecimal, "he": dotDecimal,
+	"th": dotDecimal, "ms": dotDecimal, "de-CH": {decimal: '.'},
+	"hi": {decimal: '.', groups: ",", indian: true}, "en-IN": {decimal: '.', groups: ",", indian: true},
+	"de": commaDecimal, "es": commaDecimal, "it": commaDecimal, "nl": commaDecimal,
+	"pt": commaDecimal, "tr": commaDecimal, "da": commaDecimal, "id": commaDecimal,
+	"el": commaDecimal, "ro": commaDecimal,
+	"fr": spaceGroups, "ru": spaceGroups, "uk": spaceGroups, "pl": spaceGroups, "cs": spaceGroups,
+	"sv": spaceGroups, "fi": spaceGroups, "nb": spaceGroups, "no": spaceGroups, "hu": spaceGroups,
+	"ar": arabicDigits, "fa": arabicDigits, "ur": arabicDigits,
+}
+
+func numberFormatFor(lang string) (numberFormat, error) {
+	if f, ok := numberFormats[lang]; ok {
+		return f, nil
+	}
+	if base, conf := language.Make(lang).Base(); conf == language.Exact {
+		if f, ok := numberFormats[base.String()]; ok {
+			return f, nil
+		}
+	}
+	return numberFormat{}, errors.Errorf("unsupported number locale %q", lang)
+}
+
+// numberMagnitudes maps lower cased magnitude suffixes to powers of ten.
+var numberMagnitudes = map[string]int{
+	"k": 3, "thousand": 3, "m": 6, "mn": 6, "mio": 6, "million": 6, "b": 9, "bn": 9, "mrd": 9,
+	"billion": 9, "t": 12, "tn": 12, "trillion": 12,
+}
+
+// SafeParseDecimal parses numbers as they are written in text for people, in the conventions of
+// given locale, e.g. "1.234,56" in German. The locale may be empty to guess the separators:
+// the last of "." and "," is the decimal separator if both are used, "," followed by three
+// digits is a group separator and anything else a decimal one. Spaces and apostrophes always
+// separate groups. Digits may be of any script, such as Arabic-Indic "٣٤", and currency
+// symbols are ignored. Numbers may be negative with a minus sign or accounting parentheses,
+// "(42)", and may end with a magnitude suffix: "12k", "3,5 M", "2bn". The error is a
+// *NumberError, or an unsupported locale error.
+func SafeParseDecimal(s, lang string) (Decimal, error) {
+	f := numberFormat{}
+	if lang != "" {
+		var err error
+		if f, err = numberFormatFor(lang); err != nil {
+			return Decimal{}, err
+		}
+	}
+	d, kind := parseDecimal(s, f, lang == "")
+	if kind != nil {
+		return Decimal{}, &NumberError{Input: s, Err: kind}
+	}
+	return d, nil
+}
+
+// bounds of int
+const (
+	maxIntValue = 1<<(strconv.IntSize-1) - 1
+	minIntValue = -1 << (strconv.IntSize - 1)
+)
+
+// SafeParseInt is similar to SafeParseDecimal but requires an integer which fits in an int, so
+// "1.5k" is 1500 but "1.5" is malformed.
+func SafeParseInt(s, lang string) (int, error) {
+	d, err := SafeParseDecimal(s, lang)
+	if err != nil {
+		return 0, err
+	}
+	n, rem := new(big.Int).QuoRem(d.Unscaled, pow10(d.Scale), new(big.Int))
+	if rem.Sign() != 0 {
+		return 0, &NumberError{Input: s, Err: ErrMalformedNumber}
+	}
+	if !n.IsInt64() || n.Int64() > maxIntValue || n.Int64() < minIntValue {
+		return 0, &NumberError{Input: s, Err: ErrNumberOverflow}
+	}
+	return int(n.Int64()), nil
+}
+
+// SafeParseFloat is similar to SafeParseDecimal but returns the nearest float64. Numbers too
+// large for a float64 are out of range.
+func SafeParseFloat(s, lang string) (float64, error) {
+	d, err := SafeParseDecimal(s, lang)
+	if err != nil {
+		return 0, err
+	}
+	v := d.Float64()
+	if math.IsInf(v, 0) {
+		return 0, &NumberError{Input: s, Err: ErrNumberOverflow}
+	}
+	return v, nil
+}
+
+// parseDecimal parses s with given format, or guesses the separators if auto is set.
+func parseDecimal(s string, f numberFormat, auto bool) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, ErrEmptyNumber
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = trimCurrency(s)
+	if r := []rune(s); len(r) > 0 && (r[0] == '-' || r[0] == '−' || r[0] == '+') {
+		negative = negative || r[0] != '+'
+		s = trimCurrency(string(r[1:]))
+	}
+
+	// magnitude suffix
+	exponent := 0
+	trimmed := strings.TrimRightFunc(s, unicode.IsLetter)
+	if suffix := s[len(trimmed):]; suffix != "" {
+		var ok bool
+		if exponent, ok = numberMagnitudes[strings.ToLower(suffix)]; !ok {
+			return Decimal{}, ErrMalformedNumber
+		}
+		s = trimCurrency(trimmed)
+	}
+
+	// split digits at separators
+	var groups []string
+	var separators []rune
+	var digits strings.Builder
+	for _, r := range s {
+		if v := digitValue(r); v >= 0 {
+			digits.WriteByte(byte('0' + v))
+			continue
+		}
+		if digits.Len() == 0 && len(groups) > 0 || !isNumberSeparator(r) {
+			return Decimal{}, ErrMalformedNumber
+		}
+		groups = append(groups, digits.String())
+		separators = append(separators, r)
+		digits.Reset()
+	}
+	groups = append(groups, digits.String())
+	if auto {
+		f = guessNumberFormat(separators, groups)
+	}
+
+	integer, fraction := groups, ""
+	if n := len(separators); n > 0 && separators[n-1] == f.decimal {
+		integer, fraction = groups[:n], groups[n]
+		separators = separators[:n-1]
+		if fraction == "" {
+			return Decimal{}, ErrMalformedNumber
+		}
+	}
+	for _, sep := range separators {
+		if sep == f.decimal || !isGroupSeparator(sep, f) {
+			return Decimal{}, ErrMalformedNumber
+		}
+	}
+	if !validGroups(integer, f.indian) || integer[0] == "" && fraction == "" {
+		return Decimal{}, ErrMalformedNumber
+	}
+
+	unscaled, _ := new(big.Int).SetString(strings.Join(integer, "")+fraction, 10)
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	d := Decimal{Unscaled: unscaled, Scale: len(fraction) - exponent}
+	if d.Scale < 0 {
+		d.Unscaled.Mul(d.Unscaled, pow10(-d.Scale))
+		d.Scale = 0
+	}
+	return d, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// trimCurrency removes the currency symbols and spaces surrounding s.
+func trimCurrency(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool { return unicode.IsSpace(r) || unicode.Is(unicode.Sc, r) })
+}
+
+// digitValue returns the value of a decimal digit of any script, or -1.
+func digitValue(r rune) int {
+	if '0' <= r && r <= '9' {
+		return int(r - '0')
+	}
+	if !unicode.IsDigit(r) {
+		return -1
+	}
+	// decimal digits come in runs starting with zero
+	for _, rng := range unicode.Nd.R16 {
+		if rune(rng.Lo) <= r && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if rune(rng.Lo) <= r && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10
+		}
+	}
+	return -1
+}
+
+func isNumberSeparator(r rune) bool {
+	return r == '.' || r == ',' || r == '٫' || r == '٬' || r == '\'' || r == '’' || unicode.IsSpace(r)
+}
+
+func isGroupSeparator(r rune, f numberFormat) bool {
+	return r == '\'' || r == '’' || unicode.IsSpace(r) || strings.ContainsRune(f.groups, r)
+}
+
+// guessNumberFormat returns the format of a number of given groups and separators.
+func guessNumberFormat(separators []rune, groups []string) numberFormat {
+	f := numberFormat{decimal: '.', groups: ",٬"}
+	var last rune
+	count := 0
+	for _, sep := range separators {
+		if sep == '.' || sep == ',' || sep == '٫' {
+			last = sep
+			count++
+		}
+	}
+	switch {
+	case last == '٫':
+		f.decimal = '٫'
+	case strings.ContainsRune(string(separators), '.') && strings.ContainsRune(string(separators), ','):
+		if last == ',' {
+			f = numberFormat{decimal: ',', groups: ".٬"}
+		}
+	case last == ',' && count == 1 && len(groups[len(groups)-1]) != 3:
+		f = numberFormat{decimal: ',', groups: "٬"}
+	case last == '.' && count > 1:
+		f = numberFormat{decimal: ',', groups: "."}
+	}
+	return f
+}
+
+// validGroups checks the digit groups of an integer part, e.g. "1", "234" for "1,234".
+func validGroups(groups []string, indian bool) bool {
+	for i, g := range groups {
+		size := 3
+		if indian && i < len(groups)-1 {
+			size = 2
+		}
+		switch {
+		case i == 0 && len(groups) > 1 && (g == "" || len(g) > size):
+			return false
+		case i > 0 && len(g) != size:
+			return false
+		}
+	}
+	return true
+}
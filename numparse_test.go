@@ -0,0 +1,101 @@
+package agstring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeParseDecimal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		lang     string
+		expected string
+	}{
+		{"42", "", "42"},
+		{"1.234,56", "", "1234.56"},
+		{"1,234.56", "", "1234.56"},
+		{"1 234", "", "1234"},
+		{"1,234", "", "1234"},
+		{"3,5", "", "3.5"},
+		{"1.234.567", "", "1234567"},
+		{"12k", "", "12000"},
+		{"3,5 M", "", "3500000"},
+		{"2.25bn", "", "2250000000"},
+		{"(42)", "", "-42"},
+		{"($1,234.50)", "", "-1234.50"},
+		{"−7", "", "-7"},
+		{"٣٤", "", "34"},
+		{"١٢٬٣٤٥٫٦٧", "", "12345.67"},
+		{"१२३", "", "123"},
+		{".5", "", "0.5"},
+		{"1.234", "de", "1234"},
+		{"1.234,5 €", "de-AT", "1234.5"},
+		{"1 234,56", "fr", "1234.56"},
+		{"1’234.5", "de-CH", "1234.5"},
+		{"12,34,567", "hi", "1234567"},
+		{"1,234", "en", "1234"},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := SafeParseDecimal(testCase.input, testCase.lang)
+		require.NoError(t, err, "for %q", testCase.input)
+		require.Equal(t, testCase.expected, actual.String(), "for %q", testCase.input)
+	}
+
+	errorCases := []struct {
+		input string
+		lang  string
+		kind  error
+	}{
+		{"", "", ErrEmptyNumber},
+		{"  ", "de", ErrEmptyNumber},
+		{"hello", "", ErrMalformedNumber},
+		{"12x", "", ErrMalformedNumber},
+		{"1,,234", "", ErrMalformedNumber},
+		{"12,34", "en", ErrMalformedNumber},
+		{"12.5", "de", ErrMalformedNumber},
+		{"1.", "", ErrMalformedNumber},
+		{"-", "", ErrMalformedNumber},
+	}
+	for _, testCase := range errorCases {
+		_, err := SafeParseDecimal(testCase.input, testCase.lang)
+		require.Equal(t, testCase.kind, errors.Cause(err), "for %q", testCase.input)
+	}
+
+	_, err := SafeParseDecimal("1", "xx")
+	require.Error(t, err)
+	require.Equal(t, "0", Decimal{}.String())
+}
+
+func TestSafeParseInt(t *testing.T) {
+	actual, err := SafeParseInt("1,5k", "de")
+	require.NoError(t, err)
+	require.Equal(t, 1500, actual)
+	actual, err = SafeParseInt("(1 000.0)", "")
+	require.NoError(t, err)
+	require.Equal(t, -1000, actual)
+
+	_, err = SafeParseInt("1.5", "")
+	require.Equal(t, ErrMalformedNumber, errors.Cause(err))
+	_, err = SafeParseInt("99999999999999999999", "")
+	require.Equal(t, ErrNumberOverflow, errors.Cause(err))
+	_, err = SafeParseInt("", "")
+	require.Equal(t, ErrEmptyNumber, errors.Cause(err))
+	require.EqualError(t, err, `empty number: ""`)
+}
+
+func TestSafeParseFloat(t *testing.T) {
+	actual, err := SafeParseFloat("3,5 M", "")
+	require.NoError(t, err)
+	require.Equal(t, 3.5e6, actual)
+	actual, err = SafeParseFloat("0,25", "es")
+	require.NoError(t, err)
+	require.Equal(t, 0.25, actual)
+
+	huge := "1" + strings.Repeat("0", 400)
+	_, err = SafeParseFloat(huge, "")
+	require.Equal(t, ErrNumberOverflow, errors.Cause(err))
+}
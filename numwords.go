@@ -0,0 +1,928 @@
+package agstring
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// numberKind tells how a number word combines with the preceding words.
+type numberKind int
+
+const (
+	// numberUnit words add their values, e.g. "twenty" and "one".
+	numberUnit numberKind = iota
+	// numberHundred words multiply the preceding units by a hundred, e.g. "hundred".
+	numberHundred
+	// numberScale words multiply the preceding words by their values, e.g. "thousand".
+	numberScale
+	// numberConnector words only join other words, e.g. "and".
+	numberConnector
+	// numberArticle words count as one when starting a hundred or a scale, e.g. "a" in "a
+	// hundred".
+	numberArticle
+)
+
+type numberWord struct {
+	value   int64
+	kind    numberKind
+	ordinal bool
+}
+
+// numberLanguage spells numbers out in a language.
+type numberLanguage struct {
+	lang string
+	// words maps the keys of number words, see numberKey, to their values.
+	words map[string]numberWord
+	// longest is the length of the longest key of words.
+	longest int
+	// unitsFirst tells whether units precede tens, as in German "dreiundzwanzig".
+	unitsFirst bool
+	// vigesimal tells whether 10 to 19 follow 60 and 80, as in French "soixante-dix".
+	vigesimal bool
+	// ordinalCompounds tells whether every word of an ordinal may be an ordinal, as in Spanish
+	// "vigésimo primero".
+	ordinalCompounds bool
+	cardinal         func(n int64) string
+	// ordinal returns false for numbers it can't spell out.
+	ordinal func(n int64) (string, bool)
+}
+
+type numberScaleName struct {
+	value     int64
+	one, many string
+}
+
+// maxNumberWords is the largest number spelled out.
+const maxNumberWords = 1e15 - 1
+
+var numberLanguages = map[string]*numberLanguage{
+	"en": englishNumbers(),
+	"de": germanNumbers(),
+	"fr": frenchNumbers(),
+	"es": spanishNumbers(),
+	"tr": turkishNumbers(),
+}
+
+// numberLanguageFor returns the number words of the base language of given tag.
+func numberLanguageFor(lang string) (*numberLanguage, error) {
+	if l, ok := numberLanguages[lang]; ok {
+		return l, nil
+	}
+	if base, conf := language.Make(lang).Base(); conf == language.Exact {
+		if l, ok := numberLanguages[base.String()]; ok {
+			return l, nil
+		}
+	}
+	return nil, errors.Errorf("unsupported number language %q", lang)
+}
+
+// numberMinus holds the words negating numbers.
+var numberMinus = map[string]string{"en": "minus", "de": "minus", "fr": "moins", "es": "menos", "tr": "eksi"}
+
+// ParseNumberWords converts a number spelled out in given language to int, e.g. 23 for
+// "twenty-three", German "dreiundzwanzig" or Turkish "yirmi üç". Languages en, de, fr, es and
+// tr are supported. Words may be joined with spaces, hyphens and connectors such as "and",
+// may end with an ordinal, as in "twenty-first", and may go up to the trillions. Like
+// SafeAtoi, empty string converts to zero.
+func ParseNumberWords(s, lang string) (int, error) {
+	l, err := numberLanguageFor(lang)
+	if err != nil {
+		return 0, err
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	sign := int64(1)
+	if fields := strings.Fields(s); len(fields) > 1 && strings.ToLower(fields[0]) == numberMinus[l.lang] {
+		sign = -1
+		s = strings.TrimSpace(s[len(fields[0]):])
+	}
+	n, _, ok := l.parse(s)
+	if !ok {
+		return 0, errors.Errorf("can't convert %q to int", s)
+	}
+	if n *= sign; n > maxIntValue || n < minIntValue {
+		return 0, errors.Errorf("%q is out of int range", s)
+	}
+	return int(n), nil
+}
+
+// FormatNumberWords spells n out in given language, e.g. "vingt et un" for 21 in French. See
+// FormatOrdinal for ordinals. An error is returned for unsupported languages and numbers of a
+// quadrillion or more.
+func FormatNumberWords(n int, lang string) (string, error) {
+	l, err := numberLanguageFor(lang)
+	if err != nil {
+		return "", err
+	}
+	v := int64(n)
+	prefix := ""
+	if v < 0 {
+		v = -v
+		prefix = numberMinus[l.lang] + " "
+	}
+	w, ok := l.format(v)
+	if !ok {
+		return "", errors.Errorf("can't spell out %d", n)
+	}
+	return prefix + w, nil
+}
+
+func (l *numberLanguage) add(word string, value int64, kind numberKind, ordinal bool) {
+	key, _ := numberKey(word)
+	if _, ok := l.words[key]; ok {
+		return
+	}
+	l.words[key] = numberWord{value: value, kind: kind, ordinal: ordinal}
+	l.longest = maxInt(l.longest, len(key))
+}
+
+// numberKey transliterates s to lower case ASCII letters, dropping space and hyphens. It
+// returns false if s has other characters.
+func numberKey(s string) (string, bool) {
+	s = strings.ToLower(RemoveDiacritics(s))
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case 'a' <= r && r <= 'z':
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// segment splits given key into number words, preferring longer words.
+func (l *numberLanguage) segment(key string) ([]numberWord, bool) {
+	failed := make(map[int]bool)
+	var split func(i int) ([]numberWord, bool)
+	split = func(i int) ([]numberWord, bool) {
+		if i == len(key) {
+			return nil, true
+		}
+		if failed[i] {
+			return nil, false
+		}
+		for n := minInt(l.longest, len(key)-i); n > 0; n-- {
+			w, ok := l.words[key[i:i+n]]
+			if !ok {
+				continue
+			}
+			if rest, ok := split(i + n); ok {
+				return append([]numberWord{w}, rest...), true
+			}
+		}
+		failed[i] = true
+		return nil, false
+	}
+	words, ok := split(0)
+	return words, ok && len(words) > 0
+}
+
+// parse returns the number spelled out in s and whether it is an ordinal.
+func (l *numberLanguage) parse(s string) (int64, bool, bool) {
+	key, ok := numberKey(s)
+	if !ok {
+		return 0, false, false
+	}
+	words, ok := l.segment(key)
+	if !ok {
+		return 0, false, false
+	}
+	return l.combine(words)
+}
+
+func (l *numberLanguage) combine(words []numberWord) (int64, bool, bool) {
+	// scaled holds the amounts multiplied by scales, larger scales first
+	var scaled []struct{ amount, scale int64 }
+	var total, group int64
+	ordinal := false
+	for i, w := range words {
+		switch {
+		case ordinal && !w.ordinal, ordinal && !l.ordinalCompounds:
+			// ordinal words end numbers
+			return 0, false, false
+		case w.ordinal:
+			ordinal = true
+		}
+		switch w.kind {
+		case numberConnector:
+			if i == 0 || i == len(words)-1 || words[i-1].kind == numberConnector {
+				return 0, false, false
+			}
+		case numberArticle:
+			if i > 0 || i == len(words)-1 ||
+				words[i+1].kind != numberHundred && words[i+1].kind != numberScale {
+				return 0, false, false
+			}
+			group = 1
+		case numberUnit:
+			if w.value == 0 {
+				return 0, ordinal, len(words) == 1
+			}
+			if !l.addable(group, w.value) {
+				return 0, false, false
+			}
+			group += w.value
+		case numberHundred:
+			// tens may count hundreds, as in "nineteen hundred"
+			if group >= 100 {
+				return 0, false, false
+			}
+			group = maxInt64(group, 1) * 100
+		case numberScale:
+			// a larger scale multiplies the smaller ones before it, as in Spanish "mil millones"
+			amount := group
+			for len(scaled) > 0 && scaled[len(scaled)-1].scale < w.value {
+				amount += scaled[len(scaled)-1].amount
+				total -= scaled[len(scaled)-1].amount
+				scaled = scaled[:len(scaled)-1]
+			}
+			if len(scaled) > 0 && scaled[len(scaled)-1].scale == w.value ||
+				amount > maxNumberWords/w.value {
+				return 0, false, false
+			}
+			amount = maxInt64(amount, 1) * w.value
+			scaled = append(scaled, struct{ amount, scale int64 }{amount, w.value})
+			total, group = total+amount, 0
+		}
+		if total+group > maxNumberWords {
+			return 0, false, false
+		}
+	}
+	return total + group, ordinal, true
+}
+
+// addable checks if v may be added to given group of words below a thousand.
+func (l *numberLanguage) addable(group, v int64) bool {
+	if v >= 100 {
+		// hundreds such as Spanish "doscientos"
+		return group == 0
+	}
+	low := group % 100
+	switch {
+	case low == 0:
+		return true
+	case low%10 == 0 && v < 10:
+		return true
+	case l.vigesimal && (low == 60 || low == 80) && v < 20:
+		return true
+	case l.unitsFirst && low < 10 && v >= 20 && v%10 == 0:
+		return true
+	}
+	return false
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// format spells n out, which must not be negative.
+func (l *numberLanguage) format(n int64) (string, bool) {
+	if n < 0 || n > maxNumberWords {
+		return "", false
+	}
+	return l.cardinal(n), true
+}
+
+// formatOrdinal spells the ordinal of n out, which must be positive.
+func (l *numberLanguage) formatOrdinal(n int64) (string, bool) {
+	if n < 1 || n > maxNumberWords {
+		return "", false
+	}
+	return l.ordinal(n)
+}
+
+// scaleWords spells n out with given scales, spelling the counts of the scales and the rest
+// with given functions.
+func scaleWords(n int64, scales []numberScaleName, count, rest func(n int64) string) string {
+	var parts []string
+	for _, sc := range scales {
+		if c := n / sc.value; c > 0 {
+			if c == 1 && sc.one != "" {
+				parts = append(parts, sc.one)
+			} else {
+				parts = append(parts, count(c)+" "+sc.many)
+			}
+			n %= sc.value
+		}
+	}
+	if n > 0 {
+		parts = append(parts, rest(n))
+	}
+	return strings.Join(parts, " ")
+}
+
+// mapLastWord replaces the last word of s, delimited by a space or a hyphen, with fn.
+func mapLastWord(s string, fn func(string) string) string {
+	i := strings.LastIndexAny(s, " -") + 1
+	return s[:i] + fn(s[i:])
+}
+
+var (
+	englishUnits = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen",
+		"nineteen",
+	}
+	englishTens = []string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+	englishScales = []numberScaleName{
+		{1e12, "", "trillion"}, {1e9, "", "billion"}, {1e6, "", "million"}, {1e3, "", "thousand"},
+	}
+	englishIrregularOrdinals = map[string]string{
+		"one": "first", "two": "second", "three": "third", "five": "fifth", "eight": "eighth",
+		"nine": "ninth", "twelve": "twelfth",
+	}
+)
+
+func englishNumbers() *numberLanguage {
+	l := &numberLanguage{lang: "en", words: make(map[string]numberWord)}
+	for i, w := range englishUnits {
+		l.add(w, int64(i), numberUnit, false)
+		if i > 0 {
+			l.add(englishOrdinalWord(w), int64(i), numberUnit, true)
+		}
+	}
+	for i, w := range englishTens[2:] {
+		l.add(w, int64(i+2)*10, numberUnit, false)
+		l.add(englishOrdinalWord(w), int64(i+2)*10, numberUnit, true)
+	}
+	l.add("hundred", 100, numberHundred, false)
+	l.add("hundredth", 100, numberHundred, true)
+	for _, sc := range englishScales {
+		l.add(sc.many, sc.value, numberScale, false)
+		l.add(sc.many+"th", sc.value, numberScale, true)
+	}
+	l.add("and", 0, numberConnector, false)
+	l.add("a", 1, numberArticle, false)
+	l.cardinal = func(n int64) string {
+		if n == 0 {
+			return englishUnits[0]
+		}
+		return scaleWords(n, englishScales, englishBelow1000, englishBelow1000)
+	}
+	l.ordinal = func(n int64) (string, bool) {
+		return mapLastWord(l.cardinal(n), englishOrdinalWord), true
+	}
+	return l
+}
+
+func englishBelow1000(n int64) string {
+	var parts []string
+	if h := n / 100; h > 0 {
+		parts = append(parts, englishUnits[h], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		parts = append(parts, englishTens[n/10]+"-"+englishUnits[n%10])
+	case n >= 20:
+		parts = append(parts, englishTens[n/10])
+	case n > 0:
+		parts = append(parts, englishUnits[n])
+	}
+	return strings.Join(parts, " ")
+}
+
+func englishOrdinalWord(w string) string {
+	switch {
+	case englishIrregularOrdinals[w] != "":
+		return englishIrregularOrdinals[w]
+	case strings.HasSuffix(w, "y"):
+		return strings.TrimSuffix(w, "y") + "ieth"
+	}
+	return w + "th"
+}
+
+var (
+	germanUnits = []string{
+		"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun", "zehn",
+		"elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn",
+		"neunzehn",
+	}
+	germanTens = []string{
+		"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig",
+		"neunzig",
+	}
+	germanScales = []numberScaleName{
+		{1e12, "eine Billion", "Billionen"}, {1e9, "eine Milliarde", "Milliarden"},
+		{1e6, "eine Million", "Millionen"},
+	}
+	germanOrdinalEndings = []string{"e", "en", "er", "es", "em"}
+)
+
+func germanNumbers() *numberLanguage {
+	l := &numberLanguage{lang: "de", words: make(map[string]numberWord), unitsFirst: true}
+	add := func(w string, value int64, kind numberKind) {
+		l.add(w, value, kind, false)
+		if value > 0 {
+			for _, ending := range germanOrdinalEndings {
+				l.add(germanOrdinalStem(w)+ending, value, kind, true)
+			}
+		}
+	}
+	for i, w := range germanUnits {
+		add(w, int64(i), numberUnit)
+	}
+	for i, w := range germanTens[2:] {
+		add(w, int64(i+2)*10, numberUnit)
+	}
+	l.add("ein", 1, numberUnit, false)
+	l.add("eine", 1, numberUnit, false)
+	add("hundert", 100, numberHundred)
+	add("tausend", 1e3, numberScale)
+	for _, sc := range germanScales {
+		add(strings.TrimPrefix(sc.one, "eine "), sc.value, numberScale)
+		l.add(sc.many, sc.value, numberScale, false)
+	}
+	l.add("und", 0, numberConnector, false)
+	l.cardinal = germanCardinal
+	l.ordinal = func(n int64) (string, bool) {
+		s := strings.ToLower(strings.Replace(germanCardinal(n), " ", "", -1))
+		if strings.HasPrefix(s, "eine") {
+			s = "ein" + s[len("eine"):]
+		}
+		if strings.HasSuffix(s, "ionen") || strings.HasSuffix(s, "arden") {
+			// "zweimillionen" becomes "zweimillionste"
+			s = strings.TrimSuffix(s, "en")
+		}
+		return germanOrdinalStem(s) + "e", true
+	}
+	return l
+}
+
+func germanCardinal(n int64) string {
+	if n == 0 {
+		return germanUnits[0]
+	}
+	var parts []string
+	for _, sc := range germanScales {
+		if c := n / sc.value; c > 0 {
+			if c == 1 {
+				parts = append(parts, sc.one)
+			} else {
+				parts = append(parts, germanBelow1000(c, false)+" "+sc.many)
+			}
+			n %= sc.value
+		}
+	}
+	var word string
+	if c := n / 1000; c > 0 {
+		word = germanBelow1000(c, false) + "tausend"
+		n %= 1000
+	}
+	if n > 0 {
+		word += germanBelow1000(n, true)
+	}
+	if word != "" {
+		parts = append(parts, word)
+	}
+	return strings.Join(parts, " ")
+}
+
+// germanBelow1000 spells n out, ending with "eins" rather than "ein" if final.
+func germanBelow1000(n int64, final bool) string {
+	var s string
+	if h := n / 100; h > 0 {
+		s = germanBelow1000(h, false) + "hundert"
+		n %= 100
+	}
+	switch {
+	case n == 1 && !final:
+		s += "ein"
+	case n >= 20:
+		if u := n % 10; u > 0 {
+			s += germanBelow1000(u, false) + "und"
+		}
+		s += germanTens[n/10]
+	case n > 0:
+		s += germanUnits[n]
+	}
+	return s
+}
+
+// germanOrdinalStem returns the stem of the ordinal of given number word, to which the
+// declension ending is appended, e.g. "dritt" for "drei".
+func germanOrdinalStem(w string) string {
+	w = strings.ToLower(w)
+	for _, irregular := range [][2]string{{"eins", "erst"}, {"drei", "dritt"}, {"sieben", "siebt"}} {
+		if strings.HasSuffix(w, irregular[0]) {
+			return strings.TrimSuffix(w, irregular[0]) + irregular[1]
+		}
+	}
+	switch {
+	case strings.HasSuffix(w, "acht"):
+		return w
+	case strings.HasSuffix(w, "zig"), strings.HasSuffix(w, "ßig"), strings.HasSuffix(w, "hundert"),
+		strings.HasSuffix(w, "tausend"), strings.HasSuffix(w, "ion"):
+		return w + "st"
+	case strings.HasSuffix(w, "milliarde"):
+		return strings.TrimSuffix(w, "e") + "st"
+	}
+	return w + "t"
+}
+
+var (
+	frenchUnits = []string{
+		"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix",
+		"onze", "douze", "treize", "quatorze", "quinze", "seize",
+	}
+	frenchTens = []string{
+		"", "", "vingt", "trente", "quarante", "cinquante", "soixante", "", "quatre-vingt",
+	}
+	frenchScales = []numberScaleName{
+		{1e12, "un billion", "billions"}, {1e9, "un milliard", "milliards"},
+		{1e6, "un million", "millions"},
+	}
+)
+
+func frenchNumbers() *numberLanguage {
+	l := &numberLanguage{lang: "fr", words: make(map[string]numberWord), vigesimal: true}
+	add := func(w string, value int64, kind numberKind) {
+		l.add(w, value, kind, false)
+		if value > 0 {
+			l.add(frenchOrdinalWord(w), value, kind, true)
+		}
+	}
+	for i, w := range frenchUnits {
+		add(w, int64(i), numberUnit)
+	}
+	for i, w := range frenchTens {
+		if w != "" {
+			add(w, int64(i)*10, numberUnit)
+		}
+	}
+	for _, w := range []struct {
+		word  string
+		value int64
+	}{{"une", 1}, {"septante", 70}, {"huitante", 80}, {"octante", 80}, {"nonante", 90}} {
+		add(w.word, w.value, numberUnit)
+	}
+	l.add("quatre-vingts", 80, numberUnit, false)
+	for _, w := range []string{"premier", "première", "premiers", "premières"} {
+		l.add(w, 1, numberUnit, true)
+	}
+	for _, w := range []string{"second", "seconde", "seconds", "secondes"} {
+		l.add(w, 2, numberUnit, true)
+	}
+	add("cent", 100, numberHundred)
+	l.add("cents", 100, numberHundred, false)
+	add("mille", 1e3, numberScale)
+	l.add("mil", 1e3, numberScale, false)
+	for _, sc := range frenchScales {
+		add(strings.TrimPrefix(sc.one, "un "), sc.value, numberScale)
+		l.add(sc.many, sc.value, numberScale, false)
+	}
+	l.add("et", 0, numberConnector, false)
+	l.cardinal = frenchCardinal
+	l.ordinal = func(n int64) (string, bool) {
+		if n == 1 {
+			return "premier", true
+		}
+		return mapLastWord(frenchCardinal(n), frenchOrdinalWord), true
+	}
+	return l
+}
+
+func frenchCardinal(n int64) string {
+	if n == 0 {
+		return frenchUnits[0]
+	}
+	return scaleWords(n, frenchScales, frenchBelow1000, func(n int64) string {
+		var parts []string
+		if c := n / 1000; c > 0 {
+			if c > 1 {
+				// "vingts" and "cents" lose their plural before "mille"
+				words := frenchBelow1000(c)
+				if strings.HasSuffix(words, "vingts") || strings.HasSuffix(words, "cents") {
+					words = strings.TrimSuffix(words, "s")
+				}
+				parts = append(parts, words)
+			}
+			parts = append(parts, "mille")
+			n %= 1000
+		}
+		if n > 0 {
+			parts = append(parts, frenchBelow1000(n))
+		}
+		return strings.Join(parts, " ")
+	})
+}
+
+func frenchBelow1000(n int64) string {
+	var parts []string
+	if h := n / 100; h > 0 {
+		n %= 100
+		switch {
+		case h == 1:
+			parts = append(parts, "cent")
+		case n == 0:
+			parts = append(parts, frenchUnits[h], "cents")
+		default:
+			parts = append(parts, frenchUnits[h], "cent")
+		}
+	}
+	if n > 0 {
+		parts = append(parts, frenchBelow100(n))
+	}
+	return strings.Join(parts, " ")
+}
+
+func frenchBelow100(n int64) string {
+	if n < 17 {
+		return frenchUnits[n]
+	}
+	if n < 20 {
+		return "dix-" + frenchUnits[n-10]
+	}
+	t, u := n/10, n%10
+	switch {
+	case t == 7 && u == 1:
+		return "soixante et onze"
+	case t == 7 || t == 9:
+		return frenchTens[t-1] + "-" + frenchBelow100(10+u)
+	case t == 8 && u == 0:
+		return "quatre-vingts"
+	case t == 8:
+		return "quatre-vingt-" + frenchUnits[u]
+	case u == 0:
+		return frenchTens[t]
+	case u == 1:
+		return frenchTens[t] + " et un"
+	}
+	return frenchTens[t] + "-" + frenchUnits[u]
+}
+
+func frenchOrdinalWord(w string) string {
+	switch w {
+	case "un", "une":
+		return "unième"
+	case "cinq":
+		return "cinquième"
+	case "neuf":
+		return "neuvième"
+	case "vingts", "cents", "millions", "milliards", "billions":
+		w = strings.TrimSuffix(w, "s")
+	}
+	return strings.TrimSuffix(w, "e") + "ième"
+}
+
+var (
+	spanishUnits = []string{
+		"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez",
+		"once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho",
+		"diecinueve", "veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro",
+		"veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+	}
+	spanishTens = []string{
+		"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa",
+	}
+	spanishHundreds = []string{
+		"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos",
+		"setecientos", "ochocientos", "novecientos",
+	}
+	spanishScales       = []numberScaleName{{1e12, "un billón", "billones"}, {1e6, "un millón", "millones"}}
+	spanishOrdinalUnits = []string{
+		"", "primero", "segundo", "tercero", "cuarto", "quinto", "sexto", "séptimo", "octavo",
+		"noveno",
+	}
+	spanishOrdinalTens = []string{
+		"", "décimo", "vigésimo", "trigésimo", "cuadragésimo", "quincuagésimo", "sexagésimo",
+		"septuagésimo", "octogésimo", "nonagésimo",
+	}
+	spanishOrdinalHundreds = []string{
+		"", "centésimo", "ducentésimo", "tricentésimo", "cuadringentésimo", "quingentésimo",
+		"sexcentésimo", "septingentésimo", "octingentésimo", "noningentésimo",
+	}
+)
+
+func spanishNumbers() *numberLanguage {
+	l := &numberLanguage{lang: "es", words: make(map[string]numberWord), ordinalCompounds: true}
+	for i, w := range spanishUnits {
+		l.add(w, int64(i), numberUnit, false)
+	}
+	for i, w := range spanishTens {
+		if w != "" {
+			l.add(w, int64(i)*10, numberUnit, false)
+		}
+	}
+	for i, w := range spanishHundreds[1:] {
+		l.add(w, int64(i+1)*100, numberUnit, false)
+		if strings.HasSuffix(w, "os") {
+			l.add(strings.TrimSuffix(w, "os")+"as", int64(i+1)*100, numberUnit, false)
+		}
+	}
+	for _, w := range []struct {
+		word  string
+		value int64
+	}{{"un", 1}, {"una", 1}, {"dieci", 10}, {"veinti", 20}, {"cien", 100}} {
+		l.add(w.word, w.value, numberUnit, false)
+	}
+	l.add("mil", 1e3, numberScale, false)
+	for _, sc := range spanishScales {
+		l.add(strings.TrimPrefix(sc.one, "un "), sc.value, numberScale, false)
+		l.add(sc.many, sc.value, numberScale, false)
+	}
+	l.add("y", 0, numberConnector, false)
+	addOrdinal := func(w string, value int64, kind numberKind) {
+		l.add(w, value, kind, true)
+		l.add(strings.TrimSuffix(w, "o")+"a", value, kind, true)
+	}
+	for i := 1; i < 10; i++ {
+		addOrdinal(spanishOrdinalUnits[i], int64(i), numberUnit)
+		addOrdinal(spanishOrdinalTens[i], int64(i)*10, numberUnit)
+		addOrdinal(spanishOrdinalHundreds[i], int64(i)*100, numberUnit)
+	}
+	addOrdinal("undécimo", 11, numberUnit)
+	addOrdinal("duodécimo", 12, numberUnit)
+	addOrdinal("sétimo", 7, numberUnit)
+	l.add("primer", 1, numberUnit, true)
+	l.add("tercer", 3, numberUnit, true)
+	addOrdinal("milésimo", 1e3, numberScale)
+	addOrdinal("millonésimo", 1e6, numberScale)
+	l.cardinal = func(n int64) string { return spanishCardinal(n, false) }
+	l.ordinal = spanishOrdinal
+	return l
+}
+
+// spanishCardinal spells n out, shortening a final "uno" to "un" if apocope is set, as before
+// nouns such as "mil".
+func spanishCardinal(n int64, apocope bool) string {
+	if n == 0 {
+		return spanishUnits[0]
+	}
+	count := func(n int64) string { return spanishCardinal(n, true) }
+	return scaleWords(n, spanishScales, count, func(n int64) string {
+		var parts []string
+		if c := n / 1000; c > 0 {
+			if c > 1 {
+				parts = append(parts, spanishBelow1000(c, true))
+			}
+			parts = append(parts, "mil")
+			n %= 1000
+		}
+		if n > 0 {
+			parts = append(parts, spanishBelow1000(n, apocope))
+		}
+		return strings.Join(parts, " ")
+	})
+}
+
+func spanishBelow1000(n int64, apocope bool) string {
+	if n == 100 {
+		return "cien"
+	}
+	var parts []string
+	if h := n / 100; h > 0 {
+		parts = append(parts, spanishHundreds[h])
+		n %= 100
+	}
+	switch {
+	case n >= 30 && n%10 != 0:
+		parts = append(parts, spanishTens[n/10], "y", spanishUnits[n%10])
+	case n >= 30:
+		parts = append(parts, spanishTens[n/10])
+	case n > 0:
+		parts = append(parts, spanishUnits[n])
+	}
+	s := strings.Join(parts, " ")
+	switch {
+	case apocope && strings.HasSuffix(s, "veintiuno"):
+		s = strings.TrimSuffix(s, "veintiuno") + "veintiún"
+	case apocope && strings.HasSuffix(s, "uno"):
+		s = strings.TrimSuffix(s, "o")
+	}
+	return s
+}
+
+// spanishOrdinal spells out ordinals below a million.
+func spanishOrdinal(n int64) (string, bool) {
+	if n >= 1e6 {
+		return "", false
+	}
+	var parts []string
+	if c := n / 1000; c > 0 {
+		if c == 1 {
+			parts = append(parts, "milésimo")
+		} else {
+			parts = append(parts, strings.Replace(spanishCardinal(c, true), " ", "", -1)+"milésimo")
+		}
+		n %= 1000
+	}
+	if h := n / 100; h > 0 {
+		parts = append(parts, spanishOrdinalHundreds[h])
+		n %= 100
+	}
+	switch {
+	case n == 11:
+		parts = append(parts, "undécimo")
+	case n == 12:
+		parts = append(parts, "duodécimo")
+	case n > 12 && n < 20:
+		parts = append(parts, "decimo"+spanishOrdinalUnits[n-10])
+	case n >= 10:
+		parts = append(parts, spanishOrdinalTens[n/10])
+		if n%10 > 0 {
+			parts = append(parts, spanishOrdinalUnits[n%10])
+		}
+	case n > 0:
+		parts = append(parts, spanishOrdinalUnits[n])
+	}
+	return strings.Join(parts, " "), true
+}
+
+var (
+	turkishUnits = []string{"sıfır", "bir", "iki", "üç", "dört", "beş", "altı", "yedi", "sekiz", "dokuz"}
+	turkishTens  = []string{
+		"", "on", "yirmi", "otuz", "kırk", "elli", "altmış", "yetmiş", "seksen", "doksan",
+	}
+	turkishScales = []numberScaleName{
+		{1e12, "", "trilyon"}, {1e9, "", "milyar"}, {1e6, "", "milyon"}, {1e3, "bin", "bin"},
+	}
+)
+
+func turkishNumbers() *numberLanguage {
+	l := &numberLanguage{lang: "tr", words: make(map[string]numberWord)}
+	add := func(w string, value int64, kind numberKind) {
+		l.add(w, value, kind, false)
+		if value > 0 {
+			l.add(turkishOrdinalWord(w), value, kind, true)
+		}
+	}
+	for i, w := range turkishUnits {
+		add(w, int64(i), numberUnit)
+	}
+	for i, w := range turkishTens[1:] {
+		add(w, int64(i+1)*10, numberUnit)
+	}
+	add("yüz", 100, numberHundred)
+	for _, sc := range turkishScales {
+		add(sc.many, sc.value, numberScale)
+	}
+	l.cardinal = func(n int64) string {
+		if n == 0 {
+			return turkishUnits[0]
+		}
+		return scaleWords(n, turkishScales, turkishBelow1000, turkishBelow1000)
+	}
+	l.ordinal = func(n int64) (string, bool) {
+		return mapLastWord(l.cardinal(n), turkishOrdinalWord), true
+	}
+	return l
+}
+
+func turkishBelow1000(n int64) string {
+	var parts []string
+	if h := n / 100; h > 0 {
+		if h > 1 {
+			parts = append(parts, turkishUnits[h])
+		}
+		parts = append(parts, "yüz")
+	}
+	if t := n % 100 / 10; t > 0 {
+		parts = append(parts, turkishTens[t])
+	}
+	if u := n % 10; u > 0 {
+		parts = append(parts, turkishUnits[u])
+	}
+	return strings.Join(parts, " ")
+}
+
+// turkishOrdinalWord appends the ordinal suffix to w following vowel harmony, e.g. "üçüncü"
+// for "üç".
+func turkishOrdinalWord(w string) string {
+	if w == "dört" {
+		w = "dörd"
+	}
+	suffix := "inci"
+	for _, r := range w {
+		switch r {
+		case 'a', 'ı':
+			suffix = "ıncı"
+		case 'e', 'i':
+			suffix = "inci"
+		case 'o', 'u':
+			suffix = "uncu"
+		case 'ö', 'ü':
+			suffix = "üncü"
+		}
+	}
+	if last, _ := utf8.DecodeLastRuneInString(w); strings.ContainsRune("aeıioöuü", last) {
+		// the suffix loses its vowel after a vowel
+		_, size := utf8.DecodeRuneInString(suffix)
+		suffix = suffix[size:]
+	}
+	return w + suffix
+}
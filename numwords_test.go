@@ -0,0 +1,82 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumberWords(t *testing.T) {
+	testCases := []struct {
+		input    string
+		lang     string
+		expected int
+	}{
+		{"", "en", 0},
+		{"zero", "en", 0},
+		{"twenty one", "en", 21},
+		{"Twenty-One", "en", 21},
+		{"one hundred and five", "en", 105},
+		{"three million two hundred thousand and one", "en", 3200001},
+		{"nineteen hundred", "en", 1900},
+		{"twenty-one hundred and five", "en", 2105},
+		{"a hundred", "en", 100},
+		{"a thousand and one", "en", 1001},
+		{"twenty-first", "en", 21},
+		{"minus forty two", "en", -42},
+		{"dreiundzwanzig", "de", 23},
+		{"zwei Millionen dreihunderttausend", "de", 2300000},
+		{"cent vingt", "fr", 120},
+		{"quatre-vingt-dix-sept", "fr", 97},
+		{"ciento veintitrés", "es", 123},
+		{"dos millones", "es", 2000000},
+		{"yirmi üç", "tr", 23},
+		{"iki milyar", "tr-TR", 2000000000},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := ParseNumberWords(testCase.input, testCase.lang)
+		require.NoError(t, err, "for %q", testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+	}
+
+	for _, input := range []string{
+		"and", "one one", "hello", "21", "minus", "a", "a one", "one a hundred", "one hundred hundred",
+	} {
+		_, err := ParseNumberWords(input, "en")
+		require.Error(t, err, "for %q", input)
+	}
+	_, err := ParseNumberWords("one", "xx")
+	require.Error(t, err)
+}
+
+func TestFormatNumberWords(t *testing.T) {
+	testCases := []struct {
+		n        int
+		lang     string
+		expected string
+	}{
+		{0, "en", "zero"},
+		{21, "en", "twenty-one"},
+		{-42, "en", "minus forty-two"},
+		{23, "de", "dreiundzwanzig"},
+		{21, "fr", "vingt et un"},
+		{97, "fr", "quatre-vingt-dix-sept"},
+		{123, "es", "ciento veintitrés"},
+		{23, "tr", "yirmi üç"},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := FormatNumberWords(testCase.n, testCase.lang)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, actual)
+		parsed, err := ParseNumberWords(actual, testCase.lang)
+		require.NoError(t, err)
+		require.Equal(t, testCase.n, parsed)
+	}
+
+	_, err := FormatNumberWords(1e15, "en")
+	require.Error(t, err)
+	_, err = FormatNumberWords(1, "xx")
+	require.Error(t, err)
+}
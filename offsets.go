@@ -0,0 +1,123 @@
+package agstring
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// OffsetMap maps ranges of a transformed string back to the ranges of the original string
+// it was produced from. Transformations may expand a rune into several ("ß" becomes "ss"),
+// which then all map back to the original rune, or delete runes, which are only covered by
+// ranges spanning them.
+type OffsetMap struct {
+	src, dst string
+	spans    []offsetSpan
+}
+
+// offsetSpan records that dst[dstStart:dstEnd] was produced from src[srcStart:srcEnd].
+type offsetSpan struct {
+	dstStart, dstEnd int
+	srcStart, srcEnd int
+}
+
+type offsetBuilder struct {
+	src   string
+	dst   strings.Builder
+	spans []offsetSpan
+}
+
+func (b *offsetBuilder) add(srcStart, srcEnd int, out string) {
+	if out == "" {
+		return
+	}
+	dstStart := b.dst.Len()
+	b.dst.WriteString(out)
+	b.spans = append(b.spans, offsetSpan{dstStart, b.dst.Len(), srcStart, srcEnd})
+}
+
+func (b *offsetBuilder) result() (string, *OffsetMap) {
+	dst := b.dst.String()
+	return dst, &OffsetMap{src: b.src, dst: dst, spans: b.spans}
+}
+
+// mapRunes applies fn to every rune of s and records the produced offsets.
+func mapRunes(s string, fn func(r rune) string) (string, *OffsetMap) {
+	b := offsetBuilder{src: s}
+	for i, r := range s {
+		b.add(i, i+utf8.RuneLen(r), fn(r))
+	}
+	return b.result()
+}
+
+// Source returns the original string.
+func (m *OffsetMap) Source() string { return m.src }
+
+// Transformed returns the transformed string.
+func (m *OffsetMap) Transformed() string { return m.dst }
+
+// ByteRange maps the byte range [start, end) of the transformed string to the byte range of
+// the original string it was produced from. Out of range offsets are clamped.
+func (m *OffsetMap) ByteRange(start, end int) (int, int) {
+	start, end = clamp(start, 0, len(m.dst)), clamp(end, 0, len(m.dst))
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].dstEnd > start })
+	srcStart := len(m.src)
+	if i < len(m.spans) {
+		srcStart = m.spans[i].srcStart
+	}
+	if end <= start {
+		return srcStart, srcStart
+	}
+	j := sort.Search(len(m.spans), func(j int) bool { return m.spans[j].dstStart >= end })
+	return srcStart, m.spans[j-1].srcEnd
+}
+
+// RuneRange is similar to ByteRange but both ranges are expressed in rune offsets.
+func (m *OffsetMap) RuneRange(start, end int) (int, int) {
+	srcStart, srcEnd := m.ByteRange(runeToByteOffset(m.dst, start), runeToByteOffset(m.dst, end))
+	return utf8.RuneCountInString(m.src[:srcStart]), utf8.RuneCountInString(m.src[:srcEnd])
+}
+
+// runeToByteOffset returns the byte offset of n-th rune of s, or len(s) if s is shorter.
+func runeToByteOffset(s string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
+
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
+
+// RemoveDiacriticsWithOffsets is similar to RemoveDiacritics but also returns a map from the
+// offsets of the result back to the offsets of given string.
+func RemoveDiacriticsWithOffsets(s string) (string, *OffsetMap) {
+	return mapRunes(s, unidecodeRune)
+}
+
+// NormalizeWithOffsets is similar to Normalize but also returns a map from the offsets of the
+// result back to the offsets of given string.
+func NormalizeWithOffsets(s string) (string, *OffsetMap) {
+	return mapRunes(s, normalizeRune)
+}
+
+// TransliterateWithOffsets is similar to Transliterate but also returns a map from the offsets
+// of the result back to the offsets of given string.
+func (t *Transliterator) TransliterateWithOffsets(s string) (string, *OffsetMap) {
+	b := offsetBuilder{src: s}
+	t.transliterate(s, b.add)
+	return b.result()
+}
@@ -0,0 +1,86 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveDiacriticsWithOffsets(t *testing.T) {
+	input := "Straße Łódź"
+	res, m := RemoveDiacriticsWithOffsets(input)
+	require.Equal(t, RemoveDiacritics(input), res)
+	require.Equal(t, "Strasse Lodz", res)
+	require.Equal(t, input, m.Source())
+	require.Equal(t, res, m.Transformed())
+
+	testCases := []struct {
+		start, end         int
+		expected           string
+		runeStart, runeEnd int
+	}{
+		{0, 7, "Straße", 0, 6},
+		{4, 6, "ß", 4, 5},
+		{4, 5, "ß", 4, 5},
+		{5, 8, "ße ", 4, 7},
+		{8, 12, "Łódź", 7, 11},
+		{9, 10, "ó", 8, 9},
+		{3, 3, "", 3, 3},
+		{-1, 100, input, 0, 11},
+	}
+
+	for _, testCase := range testCases {
+		start, end := m.ByteRange(testCase.start, testCase.end)
+		require.Equal(t, testCase.expected, input[start:end],
+			"for range [%d, %d)", testCase.start, testCase.end)
+		runeStart, runeEnd := m.RuneRange(testCase.start, testCase.end)
+		require.Equal(t, testCase.runeStart, runeStart)
+		require.Equal(t, testCase.runeEnd, runeEnd)
+	}
+}
+
+func TestNormalizeWithOffsets(t *testing.T) {
+	input := "Ağ-Flow, Çay!"
+	res, m := NormalizeWithOffsets(input)
+	require.Equal(t, Normalize(input), res)
+	require.Equal(t, "agflowcay", res)
+
+	testCases := []struct {
+		start, end int
+		expected   string
+	}{
+		{0, 2, "Ağ"},
+		{2, 6, "Flow"},
+		{5, 7, "w, Ç"},
+		{6, 9, "Çay"},
+		{9, 9, ""},
+	}
+
+	for _, testCase := range testCases {
+		start, end := m.ByteRange(testCase.start, testCase.end)
+		require.Equal(t, testCase.expected, input[start:end],
+			"for range [%d, %d)", testCase.start, testCase.end)
+	}
+
+	res, m = NormalizeWithOffsets("")
+	require.Equal(t, "", res)
+	start, end := m.ByteRange(0, 0)
+	require.Equal(t, 0, start)
+	require.Equal(t, 0, end)
+}
+
+func TestTransliterateWithOffsets(t *testing.T) {
+	tr, err := NewTransliterator("de")
+	require.NoError(t, err)
+
+	input := "Grüße"
+	res, m := tr.TransliterateWithOffsets(input)
+	require.Equal(t, tr.Transliterate(input), res)
+	require.Equal(t, "Gruesse", res)
+
+	start, end := m.RuneRange(2, 4)
+	require.Equal(t, 2, start)
+	require.Equal(t, 3, end)
+	start, end = m.ByteRange(4, 7)
+	require.Equal(t, "ße", input[start:end])
+}
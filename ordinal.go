@@ -0,0 +1,356 @@
+package agstring
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// OrdinalStyle selects how ordinals are written.
+type OrdinalStyle int
+
+// Ordinal styles
+const (
+	// OrdinalNumeric writes ordinals with digits, e.g. "21st" or German "21.".
+	OrdinalNumeric OrdinalStyle = iota
+	// OrdinalWords spells ordinals out, e.g. "twenty-first" or Turkish "yirmi birinci".
+	OrdinalWords
+)
+
+func (s OrdinalStyle) String() string {
+	if s == OrdinalWords {
+		return "words"
+	}
+	return "numeric"
+}
+
+// Ordinal is an ordinal number found in a string.
+type Ordinal struct {
+	// Value is the number, e.g. 21 for "21st" and "twenty-first".
+	Value int
+	// Start and End are the byte offsets of the ordinal in the searched string.
+	Start, End int
+	// Lang is the language the ordinal was found with.
+	Lang string
+	// Style tells whether the ordinal is written with digits or spelled out.
+	Style OrdinalStyle
+}
+
+type numericOrdinal struct {
+	// suffix matches the suffixes following the digits, e.g. "th" in "4th".
+	suffix *regexp.Regexp
+	// dotted tells whether a dot following the digits makes an ordinal, as in German "4.".
+	dotted bool
+	format func(n int) string
+}
+
+var numericOrdinals = map[string]numericOrdinal{
+	"en": {suffix: regexp.MustCompile(`^(?i:st|nd|rd|th)`), format: englishNumericOrdinal},
+	"fr": {
+		suffix: regexp.MustCompile(`^(?i:ère|ere|ème|eme|nde|er|re|nd|e)s?`),
+		format: func(n int) string {
+			if n == 1 {
+				return "1er"
+			}
+			return strconv.Itoa(n) + "e"
+		},
+	},
+	"de": {dotted: true, format: func(n int) string { return strconv.Itoa(n) + "." }},
+	"es": {
+		suffix: regexp.MustCompile(`^\.?(?:º|ª|(?i:er|ra))`),
+		format: func(n int) string { return strconv.Itoa(n) + ".º" },
+	},
+	"tr": {
+		suffix: regexp.MustCompile(`^['’]?(?i:inci|ıncı|uncu|üncü|nci|ncı|ncu|ncü)`),
+		dotted: true,
+		format: func(n int) string { return strconv.Itoa(n) + "." },
+	},
+}
+
+func englishNumericOrdinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// ordinalLanguages returns the number words of given languages, English by default.
+// Unsupported languages are skipped.
+func ordinalLanguages(langs []string) []*numberLanguage {
+	if len(langs) == 0 {
+		langs = []string{"en"}
+	}
+	var ls []*numberLanguage
+	for _, lang := range langs {
+		if l, err := numberLanguageFor(lang); err == nil {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// FindOrdinals returns the ordinals found in given string in the given languages, English by
+// default. Numeric ordinals are only found when their suffix directly follows the digits, so
+// "1st August" has an ordinal but "Thursday" doesn't. German and Turkish dotted ordinals such
+// as "3. März" need a word after the dot. Spelled out ordinals are found whatever their case,
+// except words which are rather nouns in context, such as "second" in "wait a second".
+func FindOrdinals(s string, langs ...string) []Ordinal {
+	var found []Ordinal
+	for _, l := range ordinalLanguages(langs) {
+		found = append(found, findNumericOrdinals(s, l.lang, false)...)
+		found = append(found, findWordOrdinals(s, l)...)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Start != found[j].Start {
+			return found[i].Start < found[j].Start
+		}
+		return found[i].End > found[j].End
+	})
+	selected := found[:0]
+	end := 0
+	for _, o := range found {
+		if o.Start >= end {
+			selected = append(selected, o)
+			end = o.End
+		}
+	}
+	return selected
+}
+
+// findNumericOrdinals returns the numeric ordinals of given language in s. A dot ending s
+// makes an ordinal if atEnd is set.
+func findNumericOrdinals(s, lang string, atEnd bool) []Ordinal {
+	o := numericOrdinals[lang]
+	var found []Ordinal
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if i == 0 || !isWordRune(prev) && prev != '.' && prev != ',' {
+			if end := o.end(s, j, atEnd); end > j {
+				if v, err := strconv.Atoi(s[i:j]); err == nil && v > 0 {
+					found = append(found, Ordinal{Value: v, Start: i, End: end, Lang: lang})
+				}
+			}
+		}
+		i = j
+	}
+	return found
+}
+
+// end returns the end of the suffix following the digits of s ending at i, or i if there is
+// none.
+func (o numericOrdinal) end(s string, i int, atEnd bool) int {
+	rest := s[i:]
+	if o.suffix != nil {
+		if loc := o.suffix.FindStringIndex(rest); loc != nil && isWordBoundary(s, i+loc[1]) {
+			return i + loc[1]
+		}
+	}
+	if o.dotted && strings.HasPrefix(rest, ".") {
+		after := rest[1:]
+		if after == "" && atEnd {
+			return i + 1
+		}
+		word := strings.TrimLeftFunc(after, unicode.IsSpace)
+		if r, _ := utf8.DecodeRuneInString(word); len(word) < len(after) && unicode.IsLetter(r) {
+			return i + 1
+		}
+	}
+	return i
+}
+
+func isDigit(b byte) bool { return '0' <= b && b <= '9' }
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+// findWordOrdinals returns the spelled out ordinals of given language in s.
+func findWordOrdinals(s string, l *numberLanguage) []Ordinal {
+	var found []Ordinal
+	// run holds the offsets of consecutive number words
+	var run [][2]int
+	flush := func() {
+		for i := 0; i < len(run); i++ {
+			for j := len(run) - 1; j >= i; j-- {
+				if v, ordinal, ok := l.parse(s[run[i][0]:run[j][1]]); ok && ordinal {
+					if !isNounOrdinal(s, run[i][0], run[j][1], l.lang) {
+						found = append(found, Ordinal{
+							Value: int(v), Start: run[i][0], End: run[j][1], Lang: l.lang, Style: OrdinalWords,
+						})
+					}
+					i = j
+					break
+				}
+			}
+		}
+		run = run[:0]
+	}
+	offset := 0
+	for _, w := range splitWords(s) {
+		start := offset
+		offset += len(w)
+		switch {
+		case strings.TrimFunc(w, func(r rune) bool { return unicode.IsSpace(r) || r == '-' }) == "":
+		case l.isNumberWord(w):
+			run = append(run, [2]int{start, offset})
+		default:
+			flush()
+		}
+	}
+	flush()
+	return found
+}
+
+// nounOrdinals maps the ordinal words which are also nouns to the words which make them nouns
+// when preceding them, e.g. "a" in "wait a second".
+var nounOrdinals = map[string]map[string]map[string]bool{
+	"en": {"second": {"a": true, "one": true, "per": true, "split": true, "each": true, "every": true}},
+	"fr": {"seconde": {"une": true, "par": true, "chaque": true}},
+}
+
+// isNounOrdinal checks if the ordinal word of s from start to end is rather a noun, as in
+// "wait a second" or "1 second".
+func isNounOrdinal(s string, start, end int, lang string) bool {
+	nouns, ok := nounOrdinals[lang][strings.ToLower(s[start:end])]
+	if !ok {
+		return false
+	}
+	before := strings.Fields(strings.ToLower(s[:start]))
+	if len(before) == 0 {
+		return false
+	}
+	previous := before[len(before)-1]
+	return nouns[previous] || leadingDigits(previous) == previous
+}
+
+// isNumberWord checks if w is made of number words.
+func (l *numberLanguage) isNumberWord(w string) bool {
+	key, ok := numberKey(w)
+	if !ok || key == "" {
+		return false
+	}
+	_, ok = l.segment(key)
+	return ok
+}
+
+// ParseOrdinal returns the value of given ordinal in any of the given languages, English by
+// default, e.g. 21 for "21st", "twenty-first", German "21." or Turkish "yirmi birinci". An
+// error is returned if the string isn't an ordinal.
+func ParseOrdinal(s string, langs ...string) (int, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range ordinalLanguages(langs) {
+		if found := findNumericOrdinals(s, l.lang, true); len(found) == 1 &&
+			found[0].Start == 0 && found[0].End == len(s) {
+			return found[0].Value, nil
+		}
+		if v, ordinal, ok := l.parse(s); ok && ordinal {
+			return int(v), nil
+		}
+	}
+	return 0, errors.Errorf("invalid ordinal %q", s)
+}
+
+// FormatOrdinal writes n as an ordinal of given language in given style, e.g. "1er" and
+// "premier" in French. Languages en, fr, de, es and tr are supported, and Spanish ordinals are
+// spelled out below a million. An error is returned for other languages and for numbers below
+// 1 or too large.
+func FormatOrdinal(n int, lang string, style OrdinalStyle) (string, error) {
+	l, err := numberLanguageFor(lang)
+	if err != nil {
+		return "", err
+	}
+	if n < 1 {
+		return "", errors.Errorf("invalid ordinal %d", n)
+	}
+	if style == OrdinalNumeric {
+		return numericOrdinals[l.lang].format(n), nil
+	}
+	if w, ok := l.formatOrdinal(int64(n)); ok {
+		return w, nil
+	}
+	return "", errors.Errorf("can't spell out ordinal %d in %q", n, lang)
+}
+
+// StripOrdinalSuffixes removes the suffixes of the numeric ordinals of given languages,
+// English by default, so "Thursday 1st August" becomes "Thursday 1 August". See FindOrdinals.
+func StripOrdinalSuffixes(s string, langs ...string) string {
+	var found []Ordinal
+	for _, l := range ordinalLanguages(langs) {
+		found = append(found, findNumericOrdinals(s, l.lang, false)...)
+	}
+	return replaceOrdinals(s, found, func(_ Ordinal, text string) string {
+		return leadingDigits(text)
+	})
+}
+
+// ConvertOrdinals rewrites the ordinals of given language in given style, so "21st" becomes
+// "twenty-first" and the other way around. Ordinals which can't be written in the style are
+// left as they are.
+func ConvertOrdinals(s, lang string, style OrdinalStyle) string {
+	return replaceOrdinals(s, FindOrdinals(s, lang), func(o Ordinal, text string) string {
+		if o.Style == style {
+			return text
+		}
+		if converted, err := FormatOrdinal(o.Value, lang, style); err == nil {
+			return converted
+		}
+		return text
+	})
+}
+
+// replaceOrdinals replaces given ordinals of s with fn. Ordinals overlapping previous ones are
+// left as they are.
+func replaceOrdinals(s string, found []Ordinal, fn func(o Ordinal, text string) string) string {
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Start < found[j].Start })
+	var b strings.Builder
+	last := 0
+	for _, o := range found {
+		if o.Start < last {
+			continue
+		}
+		b.WriteString(s[last:o.Start])
+		b.WriteString(fn(o, s[o.Start:o.End]))
+		last = o.End
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// StripOrdinalSuffixes appends a StripOrdinalSuffixes step with given languages.
+func (p *Pipeline) StripOrdinalSuffixes(langs ...string) *Pipeline {
+	langs = append([]string(nil), langs...)
+	return p.Then(stepName("StripOrdinalSuffixes", langs...), func(s string) string {
+		return StripOrdinalSuffixes(s, langs...)
+	})
+}
+
+// ConvertOrdinals appends a ConvertOrdinals step with given language and style.
+func (p *Pipeline) ConvertOrdinals(lang string, style OrdinalStyle) *Pipeline {
+	return p.Then(stepName("ConvertOrdinals", lang, style.String()), func(s string) string {
+		return ConvertOrdinals(s, lang, style)
+	})
+}
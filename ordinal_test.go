@@ -0,0 +1,154 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripOrdinalSuffixes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		langs    []string
+		expected string
+	}{
+		{"Thursday 1st August", nil, "Thursday 1 August"},
+		{"Fast Street 22nd floor", nil, "Fast Street 22 floor"},
+		{"Mon 21st of March 2018", nil, "Mon 21 of March 2018"},
+		{"the 3RD and 11th", nil, "the 3 and 11"},
+		{"1stJan 4x4th a1st", nil, "1stJan 4x4th a1st"},
+		{"le 1er et la 2e place", []string{"fr"}, "le 1 et la 2 place"},
+		{"am 3. März, 21.03.2020 und 4.", []string{"de"}, "am 3 März, 21.03.2020 und 4."},
+		{"el 1.º y la 3.ª", []string{"es"}, "el 1 y la 3"},
+		{"3'üncü ve 21. sırada", []string{"tr"}, "3 ve 21 sırada"},
+		{"1st und 2.", []string{"en", "de"}, "1 und 2."},
+		{"1st", []string{"xx"}, "1st"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, StripOrdinalSuffixes(testCase.input, testCase.langs...),
+			"for %q", testCase.input)
+	}
+	require.Equal(t, "Thursday 1XX Fast St", ReplaceDayOrdinal("Thursday 1st Fast St", "XX"))
+}
+
+func TestFindOrdinals(t *testing.T) {
+	require.Equal(t, []Ordinal{
+		{Value: 21, Start: 4, End: 8, Lang: "en"},
+		{Value: 101, Start: 13, End: 34, Lang: "en", Style: OrdinalWords},
+	}, FindOrdinals("the 21st and one hundred and first, not one", "en"))
+	require.Equal(t, []Ordinal{
+		{Value: 21, Start: 4, End: 8, Lang: "en"},
+		{Value: 101, Start: 13, End: 30, Lang: "en", Style: OrdinalWords},
+	}, FindOrdinals("the 21st and one hundred first, not one", "en"))
+	require.Equal(t, []Ordinal{
+		{Value: 23, Start: 4, End: 20, Lang: "tr", Style: OrdinalWords},
+	}, FindOrdinals("tam yirmi üçüncü kez", "tr"))
+	require.Empty(t, FindOrdinals("first aid? no, the one and only", "de"))
+
+	for _, input := range []string{
+		"wait a second", "one second left", "30 per second", "in a split second", "a 1 second delay",
+	} {
+		require.Empty(t, FindOrdinals(input, "en"), "for %q", input)
+	}
+	require.Empty(t, FindOrdinals("attendez une seconde", "fr"))
+	require.Len(t, FindOrdinals("the second time, a second", "en"), 1)
+}
+
+func TestParseOrdinal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		langs    []string
+		expected int
+	}{
+		{"21st", nil, 21},
+		{"first", nil, 1},
+		{"Twenty-Third", nil, 23},
+		{"one hundred twelfth", nil, 112},
+		{"one hundred and twelfth", nil, 112},
+		{"two thousandth", nil, 2000},
+		{"two millionth", nil, 2000000},
+		{"birinci", []string{"tr"}, 1},
+		{"yirmi üçüncü", []string{"tr"}, 23},
+		{"dördüncü", []string{"tr"}, 4},
+		{"4.", []string{"tr"}, 4},
+		{"einundzwanzigsten", []string{"de"}, 21},
+		{"dritte", []string{"de-AT"}, 3},
+		{"hundertste", []string{"de"}, 100},
+		{"premier", []string{"fr"}, 1},
+		{"vingt et unième", []string{"fr"}, 21},
+		{"quatre-vingt-dixième", []string{"fr"}, 90},
+		{"2ème", []string{"fr"}, 2},
+		{"vigésima primera", []string{"es"}, 21},
+		{"decimotercero", []string{"es"}, 13},
+		{"3.er", []string{"es"}, 3},
+		{"third", []string{"tr", "en"}, 3},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := ParseOrdinal(testCase.input, testCase.langs...)
+		require.NoError(t, err, "for %q", testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+	}
+
+	for _, input := range []string{"", "21", "one", "twenty", "one two", "firsts", "1st August", "third"} {
+		_, err := ParseOrdinal(input, "de")
+		require.Error(t, err, "for %q", input)
+	}
+}
+
+func TestFormatOrdinal(t *testing.T) {
+	testCases := []struct {
+		n        int
+		lang     string
+		numeric  string
+		expected string
+	}{
+		{1, "en", "1st", "first"},
+		{12, "en", "12th", "twelfth"},
+		{22, "en", "22nd", "twenty-second"},
+		{113, "en", "113th", "one hundred thirteenth"},
+		{1, "fr", "1er", "premier"},
+		{21, "fr", "21e", "vingt et unième"},
+		{80, "fr", "80e", "quatre-vingtième"},
+		{3, "de", "3.", "dritte"},
+		{21, "de", "21.", "einundzwanzigste"},
+		{101, "de", "101.", "einhunderterste"},
+		{21, "es", "21.º", "vigésimo primero"},
+		{13, "es", "13.º", "decimotercero"},
+		{4, "tr", "4.", "dördüncü"},
+		{60, "tr", "60.", "altmışıncı"},
+		{1000, "tr-TR", "1000.", "bininci"},
+	}
+
+	for _, testCase := range testCases {
+		numeric, err := FormatOrdinal(testCase.n, testCase.lang, OrdinalNumeric)
+		require.NoError(t, err)
+		require.Equal(t, testCase.numeric, numeric)
+		words, err := FormatOrdinal(testCase.n, testCase.lang, OrdinalWords)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, words)
+		parsed, err := ParseOrdinal(words, testCase.lang)
+		require.NoError(t, err)
+		require.Equal(t, testCase.n, parsed)
+	}
+
+	_, err := FormatOrdinal(0, "en", OrdinalWords)
+	require.Error(t, err)
+	_, err = FormatOrdinal(1, "xx", OrdinalNumeric)
+	require.Error(t, err)
+	_, err = FormatOrdinal(2000000, "es", OrdinalWords)
+	require.Error(t, err)
+}
+
+func TestConvertOrdinals(t *testing.T) {
+	require.Equal(t, "the twenty-first and second", ConvertOrdinals("the 21st and 2nd", "en", OrdinalWords))
+	require.Equal(t, "the 21st and 2nd", ConvertOrdinals("the twenty-first and second", "en", OrdinalNumeric))
+	require.Equal(t, "yirmi birinci kat", ConvertOrdinals("21. kat", "tr", OrdinalWords))
+	require.Equal(t, "le 1er jour", ConvertOrdinals("le premier jour", "fr", OrdinalNumeric))
+	require.Equal(t, "wait a second", ConvertOrdinals("wait a second", "en", OrdinalNumeric))
+
+	p := NewPipeline().StripOrdinalSuffixes("en", "fr").ConvertOrdinals("en", OrdinalNumeric)
+	require.Equal(t, "1 and 2 then 3rd", p.Apply("1st and 2e then third"))
+	require.Equal(t, `StripOrdinalSuffixes("en", "fr") -> ConvertOrdinals("en", "numeric")`, p.String())
+}
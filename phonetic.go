@@ -0,0 +1,371 @@
+package agstring
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Encoder builds phonetic keys, i.e. codes which are equal for words that sound alike, such as
+// "Smith" and "Smyth". Encoders transliterate their input with RemoveDiacritics and ignore
+// anything but letters, so they accept names written in any script.
+type Encoder interface {
+	// Name returns the name the encoder is known by in EncoderByName.
+	Name() string
+	// Encode returns the phonetic key of given string, or an empty string if it has no
+	// letters.
+	Encode(s string) string
+}
+
+var encoders = map[string]Encoder{
+	"soundex":          Soundex{},
+	"refined_soundex":  RefinedSoundex{},
+	"metaphone":        Metaphone{},
+	"double_metaphone": DoubleMetaphone{MaxLength: 4},
+	"cologne":          Cologne{},
+	"nysiis":           NYSIIS{MaxLength: 6},
+	"caverphone":       Caverphone{},
+}
+
+// EncoderByName returns the encoder with given name, configured as its algorithm was
+// originally published. See EncoderNames.
+func EncoderByName(name string) (Encoder, error) {
+	e, ok := encoders[name]
+	if !ok {
+		return nil, errors.Errorf("unknown phonetic encoder %q", name)
+	}
+	return e, nil
+}
+
+// EncoderNames returns the names of the encoders of EncoderByName.
+func EncoderNames() []string {
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Phonetic appends a step replacing strings with their phonetic key.
+func (p *Pipeline) Phonetic(e Encoder) *Pipeline {
+	return p.Then(stepName("Phonetic", e.Name()), e.Encode)
+}
+
+// phoneticText transliterates s and upper cases it, keeping only ASCII letters and single
+// spaces between words.
+func phoneticText(s string) string {
+	words := strings.FieldsFunc(strings.ToUpper(RemoveDiacritics(s)), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-'
+	})
+	var b strings.Builder
+	for _, w := range words {
+		n := b.Len()
+		for i := 0; i < len(w); i++ {
+			if 'A' <= w[i] && w[i] <= 'Z' {
+				if b.Len() == n && n > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteByte(w[i])
+			}
+		}
+	}
+	return b.String()
+}
+
+// phoneticLetters returns the letters of phoneticText.
+func phoneticLetters(s string) string {
+	return strings.Replace(phoneticText(s), " ", "", -1)
+}
+
+// limitLength cuts s to max bytes, unless max is 0.
+func limitLength(s string, max int) string {
+	if max > 0 && len(s) > max {
+		return s[:max]
+	}
+	return s
+}
+
+// Soundex is the American Soundex, as used by the US census: the first letter followed by
+// three digits, e.g. "R163" for "Robert" and "Rupert".
+type Soundex struct{}
+
+// Name implements Encoder.
+func (Soundex) Name() string { return "soundex" }
+
+// soundexCodes maps letters A to Z to their codes; vowels separate equal codes, H and W don't.
+const soundexCodes = "0123012-" + "022455" + "012623" + "01-202"
+
+// Encode implements Encoder.
+func (Soundex) Encode(s string) string {
+	letters := phoneticLetters(s)
+	if letters == "" {
+		return ""
+	}
+	code := []byte{letters[0]}
+	last := soundexCodes[letters[0]-'A']
+	for i := 1; i < len(letters) && len(code) < 4; i++ {
+		c := soundexCodes[letters[i]-'A']
+		switch {
+		case c == '-':
+			continue
+		case c != '0' && c != last:
+			code = append(code, c)
+		}
+		last = c
+	}
+	return string(code) + strings.Repeat("0", 4-len(code))
+}
+
+// RefinedSoundex is a variant of Soundex with finer letter groups and codes of any length,
+// e.g. "T6036084" for "Testing".
+type RefinedSoundex struct{}
+
+// Name implements Encoder.
+func (RefinedSoundex) Name() string { return "refined_soundex" }
+
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// Encode implements Encoder.
+func (RefinedSoundex) Encode(s string) string {
+	letters := phoneticLetters(s)
+	if letters == "" {
+		return ""
+	}
+	code := []byte{letters[0]}
+	var last byte
+	for i := 0; i < len(letters); i++ {
+		if c := refinedSoundexCodes[letters[i]-'A']; c != last {
+			code = append(code, c)
+			last = c
+		}
+	}
+	return string(code)
+}
+
+// Cologne is the Kölner Phonetik, designed for German names, e.g. "65752682" for
+// "Müller-Lüdenscheidt".
+type Cologne struct{}
+
+// Name implements Encoder.
+func (Cologne) Name() string { return "cologne" }
+
+// Encode implements Encoder.
+func (Cologne) Encode(s string) string {
+	text := phoneticText(s)
+	var code []byte
+	// last is the last code, '/' at the start and '-' after letters without a code
+	last := byte('/')
+	emit := func(c byte) {
+		if c != '-' && c != last && (c != '0' || last == '/') {
+			code = append(code, c)
+		}
+		last = c
+	}
+	var prev byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		var next byte
+		if i+1 < len(text) {
+			next = text[i+1]
+		}
+		switch ch {
+		case 'A', 'E', 'I', 'J', 'O', 'U', 'Y':
+			emit('0')
+		case 'B':
+			emit('1')
+		case 'P':
+			emit(choose(next == 'H', '3', '1'))
+		case 'D', 'T':
+			emit(choose(strings.IndexByte("CSZ", next) >= 0, '8', '2'))
+		case 'F', 'V', 'W':
+			emit('3')
+		case 'G', 'K', 'Q':
+			emit('4')
+		case 'C':
+			if last == '/' {
+				emit(choose(strings.IndexByte("AHKLOQRUX", next) >= 0, '4', '8'))
+			} else {
+				hard := strings.IndexByte("AHKOQUX", next) >= 0 && prev != 'S' && prev != 'Z'
+				emit(choose(hard, '4', '8'))
+			}
+		case 'X':
+			if prev != 'C' && prev != 'K' && prev != 'Q' {
+				emit('4')
+			}
+			emit('8')
+		case 'L':
+			emit('5')
+		case 'M', 'N':
+			emit('6')
+		case 'R':
+			emit('7')
+		case 'S', 'Z':
+			emit('8')
+		default:
+			// H and word breaks
+			if last != '/' {
+				last = '-'
+			}
+		}
+		prev = ch
+	}
+	return string(code)
+}
+
+func choose(cond bool, yes, no byte) byte {
+	if cond {
+		return yes
+	}
+	return no
+}
+
+// NYSIIS is the New York State Identification and Intelligence System code, e.g. "MCANT" for
+// "Macintosh".
+type NYSIIS struct {
+	// MaxLength limits the length of the codes, 6 in the original algorithm. Zero means no
+	// limit.
+	MaxLength int
+}
+
+// Name implements Encoder.
+func (NYSIIS) Name() string { return "nysiis" }
+
+var (
+	nysiisFirst = []struct{ from, to string }{
+		{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"},
+	}
+	nysiisLast = []struct{ from, to string }{
+		{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"},
+	}
+)
+
+// Encode implements Encoder.
+func (e NYSIIS) Encode(s string) string {
+	letters := phoneticLetters(s)
+	if letters == "" {
+		return ""
+	}
+	for _, t := range nysiisFirst {
+		if strings.HasPrefix(letters, t.from) {
+			letters = t.to + letters[len(t.from):]
+			break
+		}
+	}
+	for _, t := range nysiisLast {
+		if strings.HasSuffix(letters, t.from) {
+			letters = letters[:len(letters)-len(t.from)] + t.to
+			break
+		}
+	}
+	chars := []byte(letters)
+	key := []byte{chars[0]}
+	for i := 1; i < len(chars); i++ {
+		copy(chars[i:], nysiisTranscode(chars, i))
+		if chars[i] != chars[i-1] {
+			key = append(key, chars[i])
+		}
+	}
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > 2 && key[len(key)-2] == 'A' && key[len(key)-1] == 'Y' {
+		key = append(key[:len(key)-2], 'Y')
+	}
+	if len(key) > 1 && key[len(key)-1] == 'A' {
+		key = key[:len(key)-1]
+	}
+	return limitLength(string(key), e.MaxLength)
+}
+
+// nysiisTranscode returns the replacement of the letters of chars starting at i.
+func nysiisTranscode(chars []byte, i int) string {
+	at := func(j int) byte {
+		if j < len(chars) {
+			return chars[j]
+		}
+		return ' '
+	}
+	prev, cur, next := chars[i-1], chars[i], at(i+1)
+	isVowel := func(c byte) bool { return strings.IndexByte("AEIOU", c) >= 0 }
+	switch {
+	case cur == 'E' && next == 'V':
+		return "AF"
+	case isVowel(cur):
+		return "A"
+	case cur == 'Q':
+		return "G"
+	case cur == 'Z':
+		return "S"
+	case cur == 'M':
+		return "N"
+	case cur == 'K' && next == 'N':
+		return "NN"
+	case cur == 'K':
+		return "C"
+	case cur == 'S' && next == 'C' && at(i+2) == 'H':
+		return "SSS"
+	case cur == 'P' && next == 'H':
+		return "FF"
+	case cur == 'H' && (!isVowel(prev) || !isVowel(next)):
+		return string(prev)
+	case cur == 'W' && isVowel(prev):
+		return string(prev)
+	}
+	return string(cur)
+}
+
+// Caverphone is the Caverphone 2.0 algorithm, designed for New Zealand English names. Codes
+// are always 10 characters long, e.g. "STFNSN1111" for "Stevenson".
+type Caverphone struct{}
+
+// Name implements Encoder.
+func (Caverphone) Name() string { return "caverphone" }
+
+var caverphoneRules = func() []struct {
+	re  *regexp.Regexp
+	rep string
+} {
+	rules := [][2]string{
+		{"e$", ""},
+		{"^cough", "cou2f"}, {"^rough", "rou2f"}, {"^tough", "tou2f"}, {"^enough", "enou2f"},
+		{"^trough", "trou2f"}, {"^gn", "2n"}, {"mb$", "m2"},
+		{"cq", "2q"}, {"ci", "si"}, {"ce", "se"}, {"cy", "sy"}, {"tch", "2ch"}, {"c", "k"},
+		{"q", "k"}, {"x", "k"}, {"v", "f"}, {"dg", "2g"}, {"tio", "sio"}, {"tia", "sia"},
+		{"d", "t"}, {"ph", "fh"}, {"b", "p"}, {"sh", "s2"}, {"z", "s"},
+		{"^[aeiou]", "A"}, {"[aeiou]", "3"},
+		{"j", "y"}, {"^y3", "Y3"}, {"^y", "A"}, {"y", "3"},
+		{"3gh3", "3kh3"}, {"gh", "22"}, {"g", "k"},
+		{"s+", "S"}, {"t+", "T"}, {"p+", "P"}, {"k+", "K"}, {"f+", "F"}, {"m+", "M"}, {"n+", "N"},
+		{"w3", "W3"}, {"wh3", "Wh3"}, {"w$", "3"}, {"w", "2"},
+		{"^h", "A"}, {"h", "2"},
+		{"r3", "R3"}, {"r$", "3"}, {"r", "2"},
+		{"l3", "L3"}, {"l$", "3"}, {"l", "2"},
+		{"2", ""}, {"3$", "A"}, {"3", ""},
+	}
+	compiled := make([]struct {
+		re  *regexp.Regexp
+		rep string
+	}, len(rules))
+	for i, r := range rules {
+		compiled[i].re, compiled[i].rep = regexp.MustCompile(r[0]), r[1]
+	}
+	return compiled
+}()
+
+const caverphoneLength = 10
+
+// Encode implements Encoder.
+func (Caverphone) Encode(s string) string {
+	code := strings.ToLower(phoneticLetters(s))
+	if code == "" {
+		return ""
+	}
+	for _, rule := range caverphoneRules {
+		code = rule.re.ReplaceAllLiteralString(code, rule.rep)
+	}
+	return (code + strings.Repeat("1", caverphoneLength))[:caverphoneLength]
+}
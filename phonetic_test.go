@@ -0,0 +1,86 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoders(t *testing.T) {
+	testCases := []struct {
+		encoder  Encoder
+		input    string
+		expected string
+	}{
+		{Soundex{}, "Robert", "R163"},
+		{Soundex{}, "Rupert", "R163"},
+		{Soundex{}, "Ashcraft", "A261"},
+		{Soundex{}, "Tymczak", "T522"},
+		{Soundex{}, "Pfister", "P236"},
+		{Soundex{}, "Honeyman", "H555"},
+		{Soundex{}, "Lee", "L000"},
+		{Soundex{}, "O'Brien", "O165"},
+		{Soundex{}, "Сергей", "S620"},
+		{RefinedSoundex{}, "testing", "T6036084"},
+		{RefinedSoundex{}, "TESTING", "T6036084"},
+		{RefinedSoundex{}, "fox", "F205"},
+		{Cologne{}, "Müller-Lüdenscheidt", "65752682"},
+		{Cologne{}, "Wikipedia", "3412"},
+		{Cologne{}, "Meyer", "67"},
+		{Cologne{}, "Maier", "67"},
+		{Cologne{}, "Straße", "8278"},
+		{NYSIIS{}, "Macintosh", "MCANT"},
+		{NYSIIS{}, "Knuth", "NAT"},
+		{NYSIIS{}, "Phillipson", "FALAPSAN"},
+		{NYSIIS{}, "Schoenhoeft", "SANAFT"},
+		{NYSIIS{}, "McKnight", "MCNAGT"},
+		{NYSIIS{}, "Hunt", "HAD"},
+		{NYSIIS{}, "Carraway", "CARY"},
+		{NYSIIS{MaxLength: 6}, "Stevenson", "STAFAN"},
+		{Caverphone{}, "Stevenson", "STFNSN1111"},
+		{Caverphone{}, "Peter", "PTA1111111"},
+		{Caverphone{}, "Lee", "LA11111111"},
+		{Metaphone{}, "Knight", "NT"},
+		{Metaphone{}, "Xavier", "SFR"},
+		{Metaphone{}, "Thompson", "0MPSN"},
+		{Metaphone{}, "Phillipson", "FLPSN"},
+		{Metaphone{}, "Dumb", "TM"},
+		{Metaphone{}, "Edge", "EJ"},
+		{Metaphone{MaxLength: 4}, "Stevenson", "STFN"},
+		{DoubleMetaphone{}, "Catherine", "K0RN"},
+		{DoubleMetaphone{MaxLength: 4}, "Filipowicz", "FLPT"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, testCase.encoder.Encode(testCase.input),
+			"%s of %q", testCase.encoder.Name(), testCase.input)
+	}
+	for _, name := range EncoderNames() {
+		e, err := EncoderByName(name)
+		require.NoError(t, err)
+		require.Equal(t, "", e.Encode(""), name)
+		require.Equal(t, "", e.Encode("123 !?"), name)
+	}
+}
+
+func TestEncoderByName(t *testing.T) {
+	require.Equal(t, []string{
+		"caverphone", "cologne", "double_metaphone", "metaphone", "nysiis", "refined_soundex", "soundex",
+	}, EncoderNames())
+	for _, name := range EncoderNames() {
+		e, err := EncoderByName(name)
+		require.NoError(t, err)
+		require.Equal(t, name, e.Name())
+	}
+	_, err := EncoderByName("beider_morse")
+	require.Error(t, err)
+
+	e, _ := EncoderByName("nysiis")
+	require.Equal(t, "STAFAN", e.Encode("Stevenson"))
+}
+
+func TestPipelinePhonetic(t *testing.T) {
+	p := NewPipeline().Phonetic(Soundex{})
+	require.Equal(t, `Phonetic("soundex")`, p.String())
+	require.Equal(t, []string{"R163", "R163"}, p.ApplyAll([]string{"Robert", "Rupert"}))
+}
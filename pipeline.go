@@ -0,0 +1,222 @@
+package agstring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Step is a named string transformation used as a stage of a Pipeline.
+type Step struct {
+	Name string
+	Func func(string) string
+}
+
+// Pipeline applies a sequence of named steps to strings. Pipelines are immutable; every
+// builder method returns a new pipeline, so a base pipeline can be safely extended and shared.
+type Pipeline struct {
+	steps []Step
+}
+
+// NewPipeline creates a pipeline running given steps in order.
+func NewPipeline(steps ...Step) *Pipeline {
+	return &Pipeline{steps: append([]Step(nil), steps...)}
+}
+
+// Then returns a new pipeline with a custom step appended.
+func (p *Pipeline) Then(name string, fn func(string) string) *Pipeline {
+	return p.with(Step{Name: name, Func: fn})
+}
+
+// Append returns a new pipeline running the steps of p followed by the steps of other.
+func (p *Pipeline) Append(other *Pipeline) *Pipeline {
+	return NewPipeline(append(p.Steps(), other.Steps()...)...)
+}
+
+func (p *Pipeline) with(step Step) *Pipeline {
+	steps := make([]Step, 0, len(p.steps)+1)
+	steps = append(steps, p.steps...)
+	return &Pipeline{steps: append(steps, step)}
+}
+
+// Steps returns a copy of the steps of the pipeline.
+func (p *Pipeline) Steps() []Step { return append([]Step(nil), p.steps...) }
+
+// Len returns the number of steps in the pipeline.
+func (p *Pipeline) Len() int { return len(p.steps) }
+
+// Apply runs all steps on given string.
+func (p *Pipeline) Apply(s string) string {
+	for _, step := range p.steps {
+		s = step.Func(s)
+	}
+	return s
+}
+
+// ApplyAll runs all steps on every string of the slice. The input slice is not modified.
+func (p *Pipeline) ApplyAll(ls []string) []string {
+	if ls == nil {
+		return nil
+	}
+	out := make([]string, len(ls))
+	for i, s := range ls {
+		out[i] = p.Apply(s)
+	}
+	return out
+}
+
+// Stream runs all steps on every string received from in and sends the results to the
+// returned channel, which is closed once in is closed or ctx is done. Consumers which stop
+// reading before in is closed must cancel ctx.
+func (p *Pipeline) Stream(ctx context.Context, in <-chan string) <-chan string {
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case s, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- p.Apply(s):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// String describes the steps of the pipeline, e.g. `ReplaceMultispace -> ToLower`.
+func (p *Pipeline) String() string {
+	if len(p.steps) == 0 {
+		return "Identity"
+	}
+	names := make([]string, len(p.steps))
+	for i, step := range p.steps {
+		names[i] = step.Name
+	}
+	return strings.Join(names, " -> ")
+}
+
+// ReplaceMultispace appends a ReplaceMultispace step.
+func (p *Pipeline) ReplaceMultispace() *Pipeline {
+	return p.Then("ReplaceMultispace", ReplaceMultispace)
+}
+
+// RemoveDiacritics appends a RemoveDiacritics step.
+func (p *Pipeline) RemoveDiacritics() *Pipeline {
+	return p.Then("RemoveDiacritics", RemoveDiacritics)
+}
+
+// RemoveNonAlnum appends a RemoveNonAlnum step.
+func (p *Pipeline) RemoveNonAlnum() *Pipeline {
+	return p.Then("RemoveNonAlnum", RemoveNonAlnum)
+}
+
+// Normalize appends a Normalize step.
+func (p *Pipeline) Normalize() *Pipeline { return p.Then("Normalize", Normalize) }
+
+// ToLower appends a step changing case to lower.
+func (p *Pipeline) ToLower() *Pipeline { return p.Then("ToLower", strings.ToLower) }
+
+// ToUpper appends a step changing case to upper.
+func (p *Pipeline) ToUpper() *Pipeline { return p.Then("ToUpper", strings.ToUpper) }
+
+// TrimSpace appends a step trimming spaces from both ends.
+func (p *Pipeline) TrimSpace() *Pipeline { return p.Then("TrimSpace", strings.TrimSpace) }
+
+// Title appends a Title step.
+func (p *Pipeline) Title() *Pipeline {
+	return p.Then("Title", func(s string) string { return Title(s) })
+}
+
+// TrimSuffixes appends a TrimSuffixes step with given suffixes.
+func (p *Pipeline) TrimSuffixes(suffixes ...string) *Pipeline {
+	suffixes = append([]string(nil), suffixes...)
+	return p.Then(stepName("TrimSuffixes", suffixes...), func(s string) string {
+		return TrimSuffixes(s, suffixes...)
+	})
+}
+
+// TrimPrefixesAndSpace appends a TrimPrefixesAndSpace step with given prefixes.
+func (p *Pipeline) TrimPrefixesAndSpace(prefixes ...string) *Pipeline {
+	prefixes = append([]string(nil), prefixes...)
+	t := NewPrefixTrimmer(prefixes...)
+	return p.Then(stepName("TrimPrefixesAndSpace", prefixes...), func(s string) string {
+		if prefixes == nil || s == "" {
+			return s
+		}
+		return t.Trim(s)
+	})
+}
+
+// EmptyIf appends an EmptyIf step with given empty list.
+func (p *Pipeline) EmptyIf(emptyList ...string) *Pipeline {
+	emptyList = append([]string(nil), emptyList...)
+	return p.Then(stepName("EmptyIf", emptyList...), func(s string) string {
+		return EmptyIf(s, emptyList...)
+	})
+}
+
+// ConvertIf appends a ConvertIf step converting any string of the list to converted.
+func (p *Pipeline) ConvertIf(converted string, list ...string) *Pipeline {
+	list = append([]string(nil), list...)
+	name := stepName("ConvertIf", append([]string{converted}, list...)...)
+	return p.Then(name, func(s string) string {
+		return ConvertIf(s, converted, list...)
+	})
+}
+
+// ValueIfExists appends a ValueIfExists step looking strings up in given map.
+func (p *Pipeline) ValueIfExists(m map[string]string) *Pipeline {
+	cp := make(map[string]string, len(m))
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		cp[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return p.Then(stepName("ValueIfExists", keys...), func(s string) string {
+		return ValueIfExists(s, cp)
+	})
+}
+
+// ReplaceWholeWord appends a ReplaceWholeWord step.
+func (p *Pipeline) ReplaceWholeWord(old, replacement string) *Pipeline {
+	return p.Then(stepName("ReplaceWholeWord", old, replacement), func(s string) string {
+		return ReplaceWholeWord(s, old, replacement)
+	})
+}
+
+// ReplaceNewline appends a ReplaceNewline step.
+func (p *Pipeline) ReplaceNewline(replacements ...string) *Pipeline {
+	replacements = append([]string(nil), replacements...)
+	return p.Then(stepName("ReplaceNewline", replacements...), func(s string) string {
+		return ReplaceNewline(s, replacements...)
+	})
+}
+
+// ReplaceDayOrdinal appends a ReplaceDayOrdinal step.
+func (p *Pipeline) ReplaceDayOrdinal(replacements ...string) *Pipeline {
+	replacements = append([]string(nil), replacements...)
+	return p.Then(stepName("ReplaceDayOrdinal", replacements...), func(s string) string {
+		return ReplaceDayOrdinal(s, replacements...)
+	})
+}
+
+func stepName(name string, args ...string) string {
+	if len(args) == 0 {
+		return name
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = fmt.Sprintf("%q", arg)
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(quoted, ", "))
+}
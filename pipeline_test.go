@@ -0,0 +1,133 @@
+package agstring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineApply(t *testing.T) {
+	testCases := []struct {
+		pipeline *Pipeline
+		input    string
+		expected string
+	}{
+		{NewPipeline(), "  Ağ  Flow ", "  Ağ  Flow "},
+		{NewPipeline().Normalize(), "  Ağ  Flow ", "agflow"},
+		{
+			NewPipeline().RemoveDiacritics().ReplaceMultispace().ToLower(),
+			"  Ağ  Flow ",
+			"ag flow",
+		},
+		{
+			NewPipeline().ReplaceMultispace().TrimSuffixes("ltd", "inc").Title(),
+			"ACME   HOLDINGS  ltd",
+			"Acme Holdings",
+		},
+		{
+			NewPipeline().TrimPrefixesAndSpace("fob", "cif").ToUpper(),
+			" cif fob massive port",
+			"MASSIVE PORT",
+		},
+		{NewPipeline().TrimSpace().EmptyIf("N/A", "-"), " N/A ", ""},
+		{NewPipeline().ConvertIf("unknown", "?", "tbd"), "tbd", "unknown"},
+		{NewPipeline().ValueIfExists(map[string]string{"st": "saint"}), "st", "saint"},
+		{NewPipeline().ReplaceWholeWord("st", "saint"), "port st jose", "port saint jose"},
+		{NewPipeline().ReplaceNewline(" ").RemoveNonAlnum(), "a-1\nb_2", "a1b2"},
+		{NewPipeline().ReplaceDayOrdinal(), "21st Jan", "21 Jan"},
+		{
+			NewPipeline().Then("Reverse", func(s string) string {
+				rs := []rune(s)
+				for i, j := 0, len(rs)-1; i < j; i, j = i+1, j-1 {
+					rs[i], rs[j] = rs[j], rs[i]
+				}
+				return string(rs)
+			}),
+			"abc",
+			"cba",
+		},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t,
+			testCase.expected,
+			testCase.pipeline.Apply(testCase.input),
+			"pipeline %s", testCase.pipeline,
+		)
+	}
+}
+
+func TestPipelineIsImmutable(t *testing.T) {
+	base := NewPipeline().ReplaceMultispace()
+	lower := base.ToLower()
+	upper := base.ToUpper()
+
+	require.Equal(t, 1, base.Len())
+	require.Equal(t, "a b", lower.Apply("A  B"))
+	require.Equal(t, "A B", upper.Apply("a  b"))
+	require.Equal(t, "ReplaceMultispace -> ToLower -> ToUpper", lower.Append(NewPipeline(upper.Steps()[1:]...)).String())
+}
+
+func TestPipelineApplyAll(t *testing.T) {
+	p := NewPipeline().Normalize()
+	input := []string{"Ağ Flow", "ÇAY"}
+
+	require.Equal(t, []string{"agflow", "cay"}, p.ApplyAll(input))
+	require.Equal(t, []string{"Ağ Flow", "ÇAY"}, input)
+	require.Nil(t, p.ApplyAll(nil))
+}
+
+func TestPipelineStream(t *testing.T) {
+	p := NewPipeline().ReplaceMultispace().ToLower()
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, s := range []string{" A  B ", "C", "  D  E"} {
+			in <- s
+		}
+	}()
+
+	var out []string
+	for s := range p.Stream(context.Background(), in) {
+		out = append(out, s)
+	}
+	require.Equal(t, []string{"a b", "c", "d e"}, out)
+}
+
+func TestPipelineStreamCancel(t *testing.T) {
+	p := NewPipeline().ToLower()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string, 2)
+	in <- "A"
+	in <- "B"
+	out := p.Stream(ctx, in)
+	require.Equal(t, "a", <-out)
+	cancel()
+
+	// the stream stops although in is never closed
+	for range out {
+	}
+}
+
+func TestPipelineString(t *testing.T) {
+	testCases := []struct {
+		pipeline *Pipeline
+		expected string
+	}{
+		{NewPipeline(), "Identity"},
+		{NewPipeline().ReplaceMultispace().ToLower(), "ReplaceMultispace -> ToLower"},
+		{
+			NewPipeline().TrimSuffixes("ltd", "inc").EmptyIf("N/A"),
+			`TrimSuffixes("ltd", "inc") -> EmptyIf("N/A")`,
+		},
+		{
+			NewPipeline().ValueIfExists(map[string]string{"st": "saint", "ave": "avenue"}),
+			`ValueIfExists("ave", "st")`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, testCase.pipeline.String())
+	}
+}
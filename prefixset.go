@@ -0,0 +1,239 @@
+package agstring
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PrefixSet is a set of strings stored in a radix tree, answering which members of the set
+// prefix a string in time proportional to the length of the string, whatever the size of the
+// set. A PrefixSet is immutable once built, so it is safe for concurrent use. The zero value is
+// an empty set.
+type PrefixSet struct {
+	root prefixNode
+	size int
+}
+
+type prefixNode struct {
+	// label is the part of the members on the edge leading to the node.
+	label    string
+	terminal bool
+	// children are sorted by the first byte of their labels, which differ.
+	children []*prefixNode
+}
+
+// NewPrefixSet builds a set of given prefixes. Duplicates are stored once.
+func NewPrefixSet(prefixes ...string) *PrefixSet {
+	p := &PrefixSet{}
+	for _, prefix := range prefixes {
+		if p.root.insert(prefix) {
+			p.size++
+		}
+	}
+	return p
+}
+
+// insert adds s to the tree rooted at n and reports whether it was missing.
+func (n *prefixNode) insert(s string) bool {
+	node := n
+	for s != "" {
+		i := node.childIndex(s[0])
+		if i == len(node.children) || node.children[i].label[0] != s[0] {
+			leaf := &prefixNode{label: s, terminal: true}
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = leaf
+			return true
+		}
+		child := node.children[i]
+		common := commonPrefixLength(child.label, s)
+		if common < len(child.label) {
+			split := &prefixNode{label: child.label[:common], children: []*prefixNode{child}}
+			child.label = child.label[common:]
+			node.children[i] = split
+			child = split
+		}
+		node, s = child, s[common:]
+	}
+	added := !node.terminal
+	node.terminal = true
+	return added
+}
+
+func (n *prefixNode) childIndex(b byte) int {
+	return sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+}
+
+// child returns the child whose label starts with b, or nil.
+func (n *prefixNode) child(b byte) *prefixNode {
+	if i := n.childIndex(b); i < len(n.children) && n.children[i].label[0] == b {
+		return n.children[i]
+	}
+	return nil
+}
+
+func commonPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// Len returns the number of members of the set.
+func (p *PrefixSet) Len() int { return p.size }
+
+// Contains checks if given string is a member of the set.
+func (p *PrefixSet) Contains(s string) bool {
+	found := false
+	p.walk(s, func(prefix string) { found = len(prefix) == len(s) })
+	return found
+}
+
+// HasPrefix checks if given string starts with any member of the set.
+func (p *PrefixSet) HasPrefix(s string) bool {
+	_, ok := p.LongestPrefix(s)
+	return ok
+}
+
+// LongestPrefix returns the longest member of the set given string starts with.
+func (p *PrefixSet) LongestPrefix(s string) (string, bool) {
+	longest, ok := "", false
+	p.walk(s, func(prefix string) { longest, ok = prefix, true })
+	return longest, ok
+}
+
+// AllPrefixes returns the members of the set given string starts with, shortest first.
+func (p *PrefixSet) AllPrefixes(s string) []string {
+	var prefixes []string
+	p.walk(s, func(prefix string) { prefixes = append(prefixes, prefix) })
+	return prefixes
+}
+
+// walk calls visit with every member of the set prefixing s, shortest first.
+func (p *PrefixSet) walk(s string, visit func(prefix string)) {
+	node, depth := &p.root, 0
+	for {
+		if node.terminal {
+			visit(s[:depth])
+		}
+		if depth == len(s) {
+			return
+		}
+		node = node.child(s[depth])
+		if node == nil || !strings.HasPrefix(s[depth:], node.label) {
+			return
+		}
+		depth += len(node.label)
+	}
+}
+
+// WithPrefix returns the members of the set starting with given prefix in lexicographic order.
+// An empty prefix returns every member.
+func (p *PrefixSet) WithPrefix(prefix string) []string {
+	node, path, rest := &p.root, "", prefix
+	for rest != "" {
+		child := node.child(rest[0])
+		switch {
+		case child == nil:
+			return nil
+		case strings.HasPrefix(rest, child.label):
+			rest = rest[len(child.label):]
+		case strings.HasPrefix(child.label, rest):
+			// the prefix ends inside the label of the child
+			rest = ""
+		default:
+			return nil
+		}
+		node, path = child, path+child.label
+	}
+	var members []string
+	node.collect(path, &members)
+	return members
+}
+
+// collect appends the members below n, whose path is given, in lexicographic order.
+func (n *prefixNode) collect(path string, members *[]string) {
+	if n.terminal {
+		*members = append(*members, path)
+	}
+	for _, child := range n.children {
+		child.collect(path+child.label, members)
+	}
+}
+
+// prefixSetVersion is the first byte of serialized prefix sets.
+const prefixSetVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. Members are stored in order, each as the
+// length it shares with the previous member followed by the rest of it.
+func (p *PrefixSet) MarshalBinary() ([]byte, error) {
+	members := p.WithPrefix("")
+	data := []byte{prefixSetVersion}
+	data = appendUvarint(data, uint64(len(members)))
+	prev := ""
+	for _, m := range members {
+		common := commonPrefixLength(prev, m)
+		data = appendUvarint(data, uint64(common))
+		data = appendUvarint(data, uint64(len(m)-common))
+		data = append(data, m[common:]...)
+		prev = m
+	}
+	return data, nil
+}
+
+func appendUvarint(data []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(data, buf[:n]...)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the members of the set.
+func (p *PrefixSet) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != prefixSetVersion {
+		return errors.New("unsupported prefix set encoding")
+	}
+	data = data[1:]
+	count, err := readUvarint(&data)
+	if err != nil {
+		return err
+	}
+	set := PrefixSet{}
+	prev := ""
+	for i := uint64(0); i < count; i++ {
+		common, err := readUvarint(&data)
+		if err != nil {
+			return err
+		}
+		n, err := readUvarint(&data)
+		if err != nil {
+			return err
+		}
+		if common > uint64(len(prev)) || n > uint64(len(data)) {
+			return errors.Errorf("corrupt prefix set member %d", i)
+		}
+		m := prev[:common] + string(data[:n])
+		data = data[n:]
+		if set.root.insert(m) {
+			set.size++
+		}
+		prev = m
+	}
+	if len(data) > 0 {
+		return errors.New("trailing data after prefix set")
+	}
+	*p = set
+	return nil
+}
+
+func readUvarint(data *[]byte) (uint64, error) {
+	v, n := binary.Uvarint(*data)
+	if n <= 0 {
+		return 0, errors.New("truncated prefix set")
+	}
+	*data = (*data)[n:]
+	return v, nil
+}
@@ -0,0 +1,156 @@
+package agstring
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixSetLookup(t *testing.T) {
+	set := NewPrefixSet("+1", "+1212", "+44", "+4420", "+1", "+90", "+90212")
+	require.Equal(t, 6, set.Len())
+
+	testCases := []struct {
+		input   string
+		longest string
+		all     []string
+	}{
+		{"+12125550123", "+1212", []string{"+1", "+1212"}},
+		{"+13105550123", "+1", []string{"+1"}},
+		{"+442071234567", "+4420", []string{"+44", "+4420"}},
+		{"+4", "", nil},
+		{"+90212", "+90212", []string{"+90", "+90212"}},
+		{"", "", nil},
+		{"33123", "", nil},
+	}
+
+	for _, testCase := range testCases {
+		longest, ok := set.LongestPrefix(testCase.input)
+		require.Equal(t, testCase.longest, longest, "for %q", testCase.input)
+		require.Equal(t, testCase.longest != "", ok, "for %q", testCase.input)
+		require.Equal(t, testCase.all, set.AllPrefixes(testCase.input), "for %q", testCase.input)
+		require.Equal(t, ok, set.HasPrefix(testCase.input))
+		require.Equal(t, HasPrefix(testCase.input, set.WithPrefix("")...), ok)
+	}
+	require.True(t, set.Contains("+44"))
+	require.False(t, set.Contains("+442"))
+	require.False(t, set.Contains("+4"))
+}
+
+func TestPrefixSetEmptyMember(t *testing.T) {
+	set := NewPrefixSet("", "ab")
+	longest, ok := set.LongestPrefix("xyz")
+	require.True(t, ok)
+	require.Equal(t, "", longest)
+	require.Equal(t, []string{"", "ab"}, set.AllPrefixes("abc"))
+	require.True(t, set.Contains(""))
+
+	var empty PrefixSet
+	require.Equal(t, 0, empty.Len())
+	require.False(t, empty.HasPrefix("a"))
+	require.Empty(t, empty.WithPrefix(""))
+}
+
+func TestPrefixSetWithPrefix(t *testing.T) {
+	set := NewPrefixSet("SKU-100", "SKU-1001", "SKU-200", "SKU-1", "ACME-1", "SKU")
+
+	require.Equal(t, []string{"SKU", "SKU-1", "SKU-100", "SKU-1001", "SKU-200"}, set.WithPrefix("SKU"))
+	require.Equal(t, []string{"SKU-1", "SKU-100", "SKU-1001"}, set.WithPrefix("SKU-1"))
+	require.Equal(t, []string{"SKU-100", "SKU-1001"}, set.WithPrefix("SKU-10"))
+	require.Equal(t, []string{"ACME-1"}, set.WithPrefix("AC"))
+	require.Empty(t, set.WithPrefix("SKU-3"))
+	require.Empty(t, set.WithPrefix("SKU-1002"))
+	require.Equal(t, []string{"ACME-1", "SKU", "SKU-1", "SKU-100", "SKU-1001", "SKU-200"}, set.WithPrefix(""))
+}
+
+func TestPrefixSetRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	word := func(n int) string {
+		b := make([]byte, rnd.Intn(n+1))
+		for i := range b {
+			b[i] = "abc"[rnd.Intn(3)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 100; i++ {
+		members := make([]string, rnd.Intn(20))
+		for j := range members {
+			members[j] = word(5)
+		}
+		set := NewPrefixSet(members...)
+
+		unique := make(map[string]bool)
+		for _, m := range members {
+			unique[m] = true
+		}
+		var sorted []string
+		for m := range unique {
+			sorted = append(sorted, m)
+		}
+		sort.Strings(sorted)
+		require.Equal(t, len(sorted), set.Len())
+		require.Equal(t, sorted, set.WithPrefix(""))
+
+		for j := 0; j < 20; j++ {
+			s := word(7)
+			var all []string
+			for _, m := range sorted {
+				if strings.HasPrefix(s, m) {
+					all = append(all, m)
+				}
+			}
+			sort.Slice(all, func(a, b int) bool { return len(all[a]) < len(all[b]) })
+			require.Equal(t, all, set.AllPrefixes(s), "for %q in %q", s, sorted)
+			require.Equal(t, MatchesPrefixes(s, members), set.HasPrefix(s))
+		}
+	}
+}
+
+func TestPrefixSetBinary(t *testing.T) {
+	set := NewPrefixSet("+1", "+1212", "+44", "+4420", "", "ü")
+	data, err := set.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded PrefixSet
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, set.WithPrefix(""), decoded.WithPrefix(""))
+	require.Equal(t, set.Len(), decoded.Len())
+	longest, _ := decoded.LongestPrefix("+442071234567")
+	require.Equal(t, "+4420", longest)
+
+	require.Error(t, decoded.UnmarshalBinary(nil))
+	require.Error(t, decoded.UnmarshalBinary([]byte{9}))
+	require.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, decoded.UnmarshalBinary(append(data, 0)))
+	require.Equal(t, set.Len(), decoded.Len(), "failed decoding keeps the set")
+}
+
+func TestPrefixSetConcurrentReads(t *testing.T) {
+	set := NewPrefixSet("a", "ab", "abc", "b")
+	results := make([]string, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				results[i], _ = set.LongestPrefix("abcd")
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, longest := range results {
+		require.Equal(t, "abc", longest)
+	}
+}
+
+func TestMatchesPrefixesWithSet(t *testing.T) {
+	set := NewPrefixSet("+90", "+44")
+	require.True(t, MatchesPrefixes("+905551234", nil, set))
+	require.False(t, MatchesPrefixes("+15551234", nil, set))
+	require.True(t, MatchesPrefixes("+15551234", []string{"+1"}, nil))
+}
@@ -0,0 +1,268 @@
+package agstring
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// RomanMode selects how strictly Roman numerals are read and written.
+type RomanMode int
+
+// Roman numeral modes
+const (
+	// RomanStrict only accepts the standard forms from I to MMMCMXCIX, 1 to 3999, written all
+	// upper or all lower case.
+	RomanStrict RomanMode = iota
+	// RomanLenient also accepts mixed case, Unicode numerals such as "Ⅻ", additive forms such
+	// as "IIII" and irregular subtractions such as "IIX" or "IC", which are read right to left,
+	// subtracting the letters smaller than one on their right. Numbers from 4000 are written
+	// with repeated Ms.
+	RomanLenient
+)
+
+// RomanNumeral is a Roman numeral found in a string.
+type RomanNumeral struct {
+	Value int
+	// Start and End are the byte offsets of the numeral in the searched string.
+	Start, End int
+}
+
+var (
+	romanValues  = map[rune]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+	strictRoman  = regexp.MustCompile(`^M{0,3}(?:CM|CD|D?C{0,3})(?:XC|XL|L?X{0,3})(?:IX|IV|V?I{0,3})$`)
+	romanSymbols = []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"}, {50, "L"},
+		{40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+)
+
+// unicodeRoman returns the letters of the Unicode Roman numerals, e.g. "XII" for "Ⅻ".
+func unicodeRoman(r rune) (string, bool) {
+	switch {
+	case r >= 'Ⅰ' && r <= 'Ⅻ':
+		return formatRoman(int(r-'Ⅰ') + 1), true
+	case r >= 'ⅰ' && r <= 'ⅻ':
+		return formatRoman(int(r-'ⅰ') + 1), true
+	}
+	switch unicode.ToUpper(r) {
+	case 'Ⅼ':
+		return "L", true
+	case 'Ⅽ':
+		return "C", true
+	case 'Ⅾ':
+		return "D", true
+	case 'Ⅿ':
+		return "M", true
+	}
+	return "", false
+}
+
+// ParseRoman returns the value of given Roman numeral, e.g. 14 for "XIV". An error is returned
+// if the string isn't a numeral in given mode.
+func ParseRoman(s string, mode RomanMode) (int, error) {
+	if mode == RomanStrict {
+		upper := strings.ToUpper(s)
+		if s == "" || !strictRoman.MatchString(upper) || s != upper && s != strings.ToLower(s) {
+			return 0, errors.Errorf("invalid roman numeral %q", s)
+		}
+		return parseRoman(upper), nil
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if letters, ok := unicodeRoman(r); ok {
+			b.WriteString(letters)
+		} else if _, ok := romanValues[unicode.ToUpper(r)]; ok && r < utf8.RuneSelf {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			return 0, errors.Errorf("invalid roman numeral %q", s)
+		}
+	}
+	if b.Len() == 0 {
+		return 0, errors.Errorf("invalid roman numeral %q", s)
+	}
+	return parseRoman(b.String()), nil
+}
+
+// parseRoman returns the value of given upper case Roman letters, read right to left.
+func parseRoman(s string) int {
+	total, largest := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		v := romanValues[rune(s[i])]
+		if v < largest {
+			total -= v
+		} else {
+			total += v
+			largest = v
+		}
+	}
+	return total
+}
+
+// maxLenientRoman is the largest number FormatRoman writes in lenient mode, as larger ones
+// would only be long runs of "M".
+const maxLenientRoman = 100000
+
+// FormatRoman writes n as an upper case Roman numeral, e.g. "XIV" for 14. Strict mode supports
+// numbers from 1 to 3999, lenient mode up to 100000. An error is returned for others.
+func FormatRoman(n int, mode RomanMode) (string, error) {
+	if n < 1 || n > maxLenientRoman || mode == RomanStrict && n > 3999 {
+		return "", errors.Errorf("can't write %d as a roman numeral", n)
+	}
+	return formatRoman(n), nil
+}
+
+func formatRoman(n int) string {
+	var b strings.Builder
+	for _, s := range romanSymbols {
+		for ; n >= s.value; n -= s.value {
+			b.WriteString(s.symbol)
+		}
+	}
+	return b.String()
+}
+
+// FindRoman returns the Roman numerals of given mode found in given string. Only upper case
+// words are considered, so "Louis XVI" has a numeral but "mix" doesn't, and the single letter
+// "I" only when it follows a capitalized word and ends a sentence or precedes punctuation, as
+// in "Elizabeth I." or "World War I, ...". Words which are also numerals, such as "MIX", are
+// found too.
+func FindRoman(s string, mode RomanMode) []RomanNumeral {
+	var found []RomanNumeral
+	var previous string
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			i += size
+			continue
+		}
+		j := romanWordEnd(s, i)
+		word := s[i:j]
+		if v, err := ParseRoman(word, mode); err == nil && word == strings.ToUpper(word) &&
+			(word != "I" || isRegnalI(previous, s[j:])) {
+			found = append(found, RomanNumeral{Value: v, Start: i, End: j})
+		}
+		previous = word
+		i = j
+	}
+	return found
+}
+
+// romanWordEnd returns the end of the word starting at i. Hyphens and apostrophes followed by
+// lower case letters are part of words, so "X-ray" and "I'm" aren't numerals.
+func romanWordEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == '-' || r == '\'' || r == '’' {
+			if next, _ := utf8.DecodeRuneInString(s[i+size:]); unicode.IsLower(next) {
+				i += size
+				continue
+			}
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// isRegnalI checks if the word "I" following given word and followed by given rest is a
+// numeral rather than a pronoun.
+func isRegnalI(previous, rest string) bool {
+	first, _ := utf8.DecodeRuneInString(previous)
+	if !unicode.IsUpper(first) || previous == strings.ToUpper(previous) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(strings.TrimLeft(rest, " "))
+	return rest == "" || unicode.IsPunct(next) && next != '\''
+}
+
+// TitleOption configures Title.
+type TitleOption func(*titleOptions)
+
+type titleOptions struct {
+	keepRoman bool
+}
+
+// KeepRomanNumerals writes Roman numerals in upper case, whatever their case, so "Louis xvi"
+// becomes "Louis XVI" rather than "Louis Xvi". Numerals are only recognized after words such as
+// "Chapter" or "Part", after a capitalized name as in "World War ii", or on their own, and
+// words such as "mix" or abbreviations such as "CD" only after "Chapter" or "Part".
+func KeepRomanNumerals() TitleOption {
+	return func(o *titleOptions) { o.keepRoman = true }
+}
+
+// romanKeywords holds the words numbering what follows them, e.g. "Chapter XIV".
+var romanKeywords = map[string]bool{
+	"chapter": true, "volume": true, "vol": true, "part": true, "book": true, "act": true,
+	"scene": true, "section": true, "article": true, "appendix": true, "phase": true,
+	"season": true, "episode": true, "tome": true,
+}
+
+// romanLookalikes holds the words and abbreviations which are also Roman numerals, but rarely
+// outside of romanKeywords contexts.
+var romanLookalikes = map[string]bool{
+	"mix": true, "dix": true, "cd": true, "dc": true, "md": true, "cm": true, "mm": true,
+	"ml": true, "cl": true, "dl": true, "xl": true, "mc": true, "cc": true, "cv": true, "lx": true,
+	"mi": true, "di": true, "li": true,
+}
+
+// romanNonNames holds the capitalized words which aren't names numerals may follow, as "The"
+// in "The Vi Editor".
+var romanNonNames = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "of": true, "in": true,
+	"on": true, "at": true, "to": true, "for": true, "by": true, "with": true, "from": true,
+}
+
+// isRomanName checks if given word may be a name followed by a numeral, i.e. if it is
+// capitalized, has no digits and isn't in romanNonNames.
+func isRomanName(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r) && strings.IndexFunc(word, unicode.IsDigit) < 0 &&
+		!romanNonNames[strings.ToLower(word)]
+}
+
+// titleNumerals returns the Roman numerals of s Title keeps with KeepRomanNumerals. Single
+// letters are left out as Title writes them in upper case anyway.
+func titleNumerals(s string) []RomanNumeral {
+	var words [][2]int
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			i += size
+			continue
+		}
+		j := romanWordEnd(s, i)
+		words = append(words, [2]int{i, j})
+		i = j
+	}
+	var found []RomanNumeral
+	for k, w := range words {
+		word := strings.ToLower(s[w[0]:w[1]])
+		v, err := ParseRoman(word, RomanStrict)
+		if err != nil || len(word) == 1 {
+			continue
+		}
+		var previous string
+		if k > 0 {
+			previous = strings.ToLower(s[words[k-1][0]:words[k-1][1]])
+		}
+		switch {
+		case romanKeywords[previous]:
+		case romanLookalikes[word]:
+			continue
+		case k == 0 && len(words) > 1, k > 0 && !isRomanName(s[words[k-1][0]:words[k-1][1]]):
+			// a numeral needs a name before it
+			continue
+		}
+		found = append(found, RomanNumeral{Value: v, Start: w[0], End: w[1]})
+	}
+	return found
+}
@@ -0,0 +1,117 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoman(t *testing.T) {
+	testCases := []struct {
+		input   string
+		strict  int
+		lenient int
+	}{
+		{"XIV", 14, 14},
+		{"xiv", 14, 14},
+		{"MCMXCIV", 1994, 1994},
+		{"MMMCMXCIX", 3999, 3999},
+		{"IIII", 0, 4},
+		{"IIX", 0, 8},
+		{"IC", 0, 99},
+		{"XiV", 0, 14},
+		{"MMMM", 0, 4000},
+		{"Ⅻ", 0, 12},
+		{"ⅯⅯⅩⅩ", 0, 2020},
+		{"", 0, 0},
+		{"XIVA", 0, 0},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := ParseRoman(testCase.input, RomanStrict)
+		if testCase.strict == 0 {
+			require.Error(t, err, "for %q", testCase.input)
+		} else {
+			require.NoError(t, err, "for %q", testCase.input)
+			require.Equal(t, testCase.strict, actual, "for %q", testCase.input)
+		}
+		actual, err = ParseRoman(testCase.input, RomanLenient)
+		if testCase.lenient == 0 {
+			require.Error(t, err, "for %q", testCase.input)
+		} else {
+			require.NoError(t, err, "for %q", testCase.input)
+			require.Equal(t, testCase.lenient, actual, "for %q", testCase.input)
+		}
+	}
+}
+
+func TestFormatRoman(t *testing.T) {
+	for n := 1; n <= 3999; n++ {
+		s, err := FormatRoman(n, RomanStrict)
+		require.NoError(t, err)
+		parsed, err := ParseRoman(s, RomanStrict)
+		require.NoError(t, err)
+		require.Equal(t, n, parsed)
+	}
+	s, err := FormatRoman(14, RomanStrict)
+	require.NoError(t, err)
+	require.Equal(t, "XIV", s)
+	s, err = FormatRoman(4001, RomanLenient)
+	require.NoError(t, err)
+	require.Equal(t, "MMMMI", s)
+
+	_, err = FormatRoman(0, RomanLenient)
+	require.Error(t, err)
+	_, err = FormatRoman(100001, RomanLenient)
+	require.Error(t, err)
+	_, err = FormatRoman(4000, RomanStrict)
+	require.Error(t, err)
+}
+
+func TestFindRoman(t *testing.T) {
+	require.Equal(t, []RomanNumeral{{Value: 14, Start: 8, End: 11}, {Value: 16, Start: 19, End: 22}},
+		FindRoman("Chapter XIV: Louis XVI", RomanStrict))
+	require.Equal(t, []RomanNumeral{{Value: 1, Start: 10, End: 11}, {Value: 1, Start: 23, End: 24}},
+		FindRoman("Elizabeth I. World War I, and I think I'm done", RomanStrict))
+	require.Empty(t, FindRoman("I said civil mix, X-ray VIIII", RomanStrict))
+	require.Equal(t, []RomanNumeral{{Value: 9, Start: 24, End: 29}},
+		FindRoman("I said civil mix, X-ray VIIII", RomanLenient))
+	require.Equal(t, []RomanNumeral{{Value: 14, Start: 6, End: 9}, {Value: 20, Start: 10, End: 12}},
+		FindRoman("pages XIV-XX", RomanStrict))
+}
+
+func TestTitleKeepRomanNumerals(t *testing.T) {
+	require.Equal(t, "Louis Xvi", Title("LOUIS XVI"))
+	require.Equal(t, "Louis XVI", Title("LOUIS XVI", KeepRomanNumerals()))
+	require.Equal(t, "Chapter XIV, Part II", Title("chapter XIV, part II", KeepRomanNumerals()))
+	require.Equal(t, "Civil War II", Title("Civil War ii", KeepRomanNumerals()))
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Louis xvi", "Louis XVI"},
+		{"LOUIS XVI", "Louis XVI"},
+		{"louis xvi", "Louis Xvi"},
+		{"Louis Xvi of France", "Louis XVI Of France"},
+		{"chapter xiv, vol. iii", "Chapter XIV, Vol. III"},
+		{"xiv", "XIV"},
+		{"henry v", "Henry V"},
+		{"MIX CD", "Mix Cd"},
+		{"the mix", "The Mix"},
+		{"WASHINGTON DC", "Washington Dc"},
+		{"john smith md", "John Smith Md"},
+		{"chapter dc", "Chapter DC"},
+		{"civil liberties", "Civil Liberties"},
+		{"room 12 iv", "Room 12 Iv"},
+		{"the vi editor", "The Vi Editor"},
+		{"The vi editor", "The Vi Editor"},
+		{"president xi visits", "President Xi Visits"},
+		{"set of vi", "Set Of Vi"},
+		{"Set Of vi", "Set Of Vi"},
+	}
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Title(testCase.input, KeepRomanNumerals()),
+			"for %q", testCase.input)
+	}
+}
@@ -0,0 +1,284 @@
+package agstring
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	funk "github.com/thoas/go-funk"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// RulesError reports an invalid cleaning rules document together with the offending line.
+type RulesError struct {
+	// Path is the file the document was loaded from, empty if it wasn't loaded from a file.
+	Path string
+	Line int
+	Msg  string
+}
+
+func (e *RulesError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("%s: line %d: %s", e.Path, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+func rulesErrorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &RulesError{Line: n.Line, Msg: fmt.Sprintf(format, args...)}
+}
+
+var ruleSteps = map[string]func(*Pipeline) *Pipeline{
+	"replace_multispace": (*Pipeline).ReplaceMultispace,
+	"remove_diacritics":  (*Pipeline).RemoveDiacritics,
+	"remove_non_alnum":   (*Pipeline).RemoveNonAlnum,
+	"normalize":          (*Pipeline).Normalize,
+	"lower":              (*Pipeline).ToLower,
+	"upper":              (*Pipeline).ToUpper,
+	"trim_space":         (*Pipeline).TrimSpace,
+	"title":              (*Pipeline).Title,
+	"normalize_space": func(p *Pipeline) *Pipeline {
+		return p.NormalizeSpace(SpaceOptions{})
+	},
+}
+
+var ruleStepsWithArgs = map[string]func(*Pipeline, *yaml.Node) (*Pipeline, error){
+	"trim_suffixes": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		ls, err := ruleStrings(n)
+		return p.TrimSuffixes(ls...), err
+	},
+	"trim_prefixes": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		ls, err := ruleStrings(n)
+		return p.TrimPrefixesAndSpace(ls...), err
+	},
+	"empty_if": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		ls, err := ruleStrings(n)
+		return p.EmptyIf(ls...), err
+	},
+	"convert_if": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		fields, err := ruleFields(n, "to", "values")
+		if err != nil {
+			return nil, err
+		}
+		to, err := ruleString(fields["to"])
+		if err != nil {
+			return nil, err
+		}
+		ls, err := ruleStrings(fields["values"])
+		return p.ConvertIf(to, ls...), err
+	},
+	"value_map": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		m, err := ruleStringMap(n)
+		return p.ValueIfExists(m), err
+	},
+	"replace_whole_word": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		fields, err := ruleFields(n, "old", "new")
+		if err != nil {
+			return nil, err
+		}
+		old, err := ruleString(fields["old"])
+		if err != nil {
+			return nil, err
+		}
+		replacement, err := ruleString(fields["new"])
+		return p.ReplaceWholeWord(old, replacement), err
+	},
+	"replace_newline": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		rep, err := ruleString(n)
+		return p.ReplaceNewline(rep), err
+	},
+	"replace_day_ordinal": func(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+		rep, err := ruleString(n)
+		return p.ReplaceDayOrdinal(rep), err
+	},
+}
+
+// ParseRules builds a pipeline from a JSON or YAML cleaning rules document. The document
+// holds a `steps` list; every step is either the name of a step without arguments or a
+// mapping from a step name to its arguments:
+//
+//	steps:
+//	  - replace_multispace
+//	  - empty_if: ["N/A", "-", "none"]
+//	  - trim_suffixes: [ltd, inc]
+//	  - trim_prefixes: [dr, mr]
+//	  - convert_if: {to: unknown, values: ["?", tbd]}
+//	  - value_map: {st: saint, ave: avenue}
+//	  - replace_whole_word: {old: st, new: saint}
+//	  - replace_newline: " "
+//	  - lower
+//
+// Invalid documents are reported with a *RulesError carrying the line of the problem.
+func ParseRules(data []byte) (*Pipeline, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "can't parse cleaning rules")
+	}
+	if len(doc.Content) == 0 {
+		return nil, &RulesError{Line: 1, Msg: "empty cleaning rules document"}
+	}
+	fields, err := ruleFields(doc.Content[0], "steps")
+	if err != nil {
+		return nil, err
+	}
+	steps := resolveAlias(fields["steps"])
+	if steps.Kind != yaml.SequenceNode {
+		return nil, rulesErrorf(steps, "steps must be a list")
+	}
+	p := NewPipeline()
+	for _, step := range steps.Content {
+		if p, err = ruleStep(p, resolveAlias(step)); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// LoadRules reads a JSON or YAML cleaning rules document and builds a pipeline from it.
+// See ParseRules for the format of the document.
+func LoadRules(r io.Reader) (*Pipeline, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read cleaning rules")
+	}
+	return ParseRules(data)
+}
+
+// LoadRulesFile reads cleaning rules from given file. See ParseRules for the format. Invalid
+// documents are reported with a *RulesError carrying the path of the file.
+func LoadRulesFile(path string) (*Pipeline, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read cleaning rules")
+	}
+	p, err := ParseRules(data)
+	if rulesErr, ok := err.(*RulesError); ok {
+		rulesErr.Path = path
+		return nil, rulesErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return p, nil
+}
+
+func ruleStep(p *Pipeline, n *yaml.Node) (*Pipeline, error) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if build, ok := ruleSteps[n.Value]; ok {
+			return build(p), nil
+		}
+		if _, ok := ruleStepsWithArgs[n.Value]; ok {
+			return nil, rulesErrorf(n, "step %q requires arguments", n.Value)
+		}
+		return nil, rulesErrorf(n, "unknown step %q", n.Value)
+	case yaml.MappingNode:
+		if len(n.Content) != 2 {
+			return nil, rulesErrorf(n, "step must have exactly one name")
+		}
+		name, args := n.Content[0], resolveAlias(n.Content[1])
+		if build, ok := ruleStepsWithArgs[name.Value]; ok {
+			return build(p, args)
+		}
+		if build, ok := ruleSteps[name.Value]; ok {
+			if args.Tag != "!!null" {
+				return nil, rulesErrorf(args, "step %q takes no arguments", name.Value)
+			}
+			return build(p), nil
+		}
+		return nil, rulesErrorf(name, "unknown step %q", name.Value)
+	}
+	return nil, rulesErrorf(n, "step must be a name or a mapping")
+}
+
+// ruleFields returns the values of a mapping node, requiring exactly given keys.
+func ruleFields(n *yaml.Node, keys ...string) (map[string]*yaml.Node, error) {
+	n = resolveAlias(n)
+	if n.Kind != yaml.MappingNode {
+		return nil, rulesErrorf(n, "expected a mapping with keys %s", strings.Join(keys, ", "))
+	}
+	fields := make(map[string]*yaml.Node, len(keys))
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key := n.Content[i]
+		if !funk.ContainsString(keys, key.Value) {
+			return nil, rulesErrorf(key, "unknown key %q", key.Value)
+		}
+		if _, ok := fields[key.Value]; ok {
+			return nil, rulesErrorf(key, "duplicate key %q", key.Value)
+		}
+		fields[key.Value] = resolveAlias(n.Content[i+1])
+	}
+	for _, key := range keys {
+		if _, ok := fields[key]; !ok {
+			return nil, rulesErrorf(n, "missing key %q", key)
+		}
+	}
+	return fields, nil
+}
+
+func ruleString(n *yaml.Node) (string, error) {
+	if n.Kind != yaml.ScalarNode {
+		return "", rulesErrorf(n, "expected a string")
+	}
+	return n.Value, nil
+}
+
+func ruleStrings(n *yaml.Node) ([]string, error) {
+	if n.Kind != yaml.SequenceNode {
+		return nil, rulesErrorf(n, "expected a list of strings")
+	}
+	ls := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		s, err := ruleString(resolveAlias(item))
+		if err != nil {
+			return nil, err
+		}
+		ls = append(ls, s)
+	}
+	return ls, nil
+}
+
+func ruleStringMap(n *yaml.Node) (map[string]string, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, rulesErrorf(n, "expected a mapping of strings")
+	}
+	m := make(map[string]string, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, err := ruleString(n.Content[i])
+		if err != nil {
+			return nil, err
+		}
+		v, err := ruleString(resolveAlias(n.Content[i+1]))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := m[k]; ok {
+			return nil, rulesErrorf(n.Content[i], "duplicate key %q", k)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+// RuleNames returns the names of the steps usable in cleaning rules documents.
+func RuleNames() []string {
+	names := make([]string, 0, len(ruleSteps)+len(ruleStepsWithArgs))
+	for name := range ruleSteps {
+		names = append(names, name)
+	}
+	for name := range ruleStepsWithArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
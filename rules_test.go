@@ -0,0 +1,136 @@
+package agstring
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlRules = `
+# cleaning rules for port names
+steps:
+  - replace_multispace
+  - empty_if: ["N/A", "-", "none"]
+  - trim_suffixes: [port, harbour]
+  - trim_prefixes: [cif, fob]
+  - convert_if: {to: unknown, values: ["?", tbd]}
+  - value_map:
+      st: saint
+  - replace_whole_word: {old: pt, new: port}
+  - lower
+`
+
+const jsonRules = `{
+	"steps": [
+		"replace_multispace",
+		{"empty_if": ["N/A", "-", "none"]},
+		{"trim_suffixes": ["port", "harbour"]},
+		{"trim_prefixes": ["cif", "fob"]},
+		{"convert_if": {"to": "unknown", "values": ["?", "tbd"]}},
+		{"value_map": {"st": "saint"}},
+		{"replace_whole_word": {"old": "pt", "new": "port"}},
+		"lower"
+	]
+}`
+
+func TestParseRules(t *testing.T) {
+	testCases := []struct {
+		input, expected string
+	}{
+		{"N/A", ""},
+		{"none", ""},
+		{"tbd", "unknown"},
+		{"st", "saint"},
+		{"cif  fob Santos   port", "santos"},
+		{"pt  Said", "port said"},
+	}
+
+	for _, rules := range []string{yamlRules, jsonRules} {
+		p, err := ParseRules([]byte(rules))
+		require.NoError(t, err)
+		require.Equal(t, 8, p.Len())
+		for _, testCase := range testCases {
+			require.Equal(t, testCase.expected, p.Apply(testCase.input), "for input %q", testCase.input)
+		}
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	p, err := LoadRules(strings.NewReader("steps: [normalize, {replace_newline: ' '}]"))
+	require.NoError(t, err)
+	require.Equal(t, `Normalize -> ReplaceNewline(" ")`, p.String())
+	require.Equal(t, "agflow", p.Apply("Ağ-Flow"))
+
+	_, err = LoadRulesFile("testdata/missing.yaml")
+	require.Error(t, err)
+}
+
+func TestLoadRulesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agstring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("steps:\n  - lower\n"), 0644))
+	p, err := LoadRulesFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "ToLower", p.String())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("steps:\n  - lower\n  - shout\n"), 0644))
+	_, err = LoadRulesFile(path)
+	rulesErr, ok := err.(*RulesError)
+	require.True(t, ok, "got %v", err)
+	require.Equal(t, path, rulesErr.Path)
+	require.Equal(t, 3, rulesErr.Line)
+	require.Equal(t, path+`: line 3: unknown step "shout"`, err.Error())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("steps: [lower"), 0644))
+	_, err = LoadRulesFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), path)
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	testCases := []struct {
+		rules string
+		line  int
+		msg   string
+	}{
+		{"", 1, "empty cleaning rules document"},
+		{"steps: lower", 1, "steps must be a list"},
+		{"rules: []", 1, `unknown key "rules"`},
+		{"steps:\n  - lower\n  - shout", 3, `unknown step "shout"`},
+		{"steps:\n  - empty_if", 2, `step "empty_if" requires arguments`},
+		{"steps:\n  - lower: [a]", 2, `step "lower" takes no arguments`},
+		{"steps:\n  - trim_suffixes: ltd", 2, "expected a list of strings"},
+		{"steps:\n  - trim_suffixes:\n    - ltd\n    - [inc]", 4, "expected a string"},
+		{"steps:\n  - convert_if: {to: x}", 2, `missing key "values"`},
+		{"steps:\n  - convert_if:\n      to: x\n      value: [a]", 4, `unknown key "value"`},
+		{"steps:\n  - value_map: {a: b, a: c}", 2, `duplicate key "a"`},
+		{"{\n\"steps\": [\n\"lower\",\n{\"emptyif\": []}\n]\n}", 4, `unknown step "emptyif"`},
+	}
+
+	for _, testCase := range testCases {
+		_, err := ParseRules([]byte(testCase.rules))
+		require.Error(t, err, "for rules %q", testCase.rules)
+		rulesErr, ok := err.(*RulesError)
+		require.True(t, ok, "for rules %q got %v", testCase.rules, err)
+		require.Equal(t, testCase.line, rulesErr.Line, "for rules %q", testCase.rules)
+		require.Equal(t, testCase.msg, rulesErr.Msg)
+	}
+
+	_, err := ParseRules([]byte("steps: [lower"))
+	require.Error(t, err)
+}
+
+func TestRuleNames(t *testing.T) {
+	names := RuleNames()
+	require.Contains(t, names, "trim_suffixes")
+	require.Contains(t, names, "lower")
+	require.True(t, sort.StringsAreSorted(names))
+}
@@ -0,0 +1,350 @@
+package agstring
+
+// Metric is an edit distance between strings. Distances are counted in runes, so multi-byte
+// characters count as a single edit. All methods accept optional normalizers, such as
+// Normalize, which are applied to both strings before measuring them.
+type Metric struct {
+	name string
+	// distance returns the distance of a and b, or any value above max when max isn't negative
+	// and the distance exceeds it.
+	distance func(a, b []rune, max int) int
+	// maxDistance returns the largest possible distance of strings of given lengths.
+	maxDistance func(la, lb int) int
+}
+
+// Edit distances
+var (
+	// Levenshtein counts insertions, deletions and substitutions.
+	Levenshtein = Metric{"Levenshtein", levenshtein, longest}
+	// OSA (optimal string alignment, or restricted Damerau-Levenshtein distance) also counts
+	// transpositions of adjacent runes, but doesn't edit any substring more than once, so
+	// "ca" and "abc" are 3 edits apart.
+	OSA = Metric{"OSA", osa, longest}
+	// DamerauLevenshtein counts insertions, deletions, substitutions and transpositions of
+	// adjacent runes without restrictions, so "ca" and "abc" are 2 edits apart.
+	DamerauLevenshtein = Metric{"DamerauLevenshtein", damerauLevenshtein, longest}
+	// LCS (or Indel distance) counts insertions and deletions, i.e. the runes which are not
+	// part of the longest common subsequence.
+	LCS = Metric{"LCS", indel, func(la, lb int) int { return la + lb }}
+	// Hamming counts the positions at which the runes differ. Runes beyond the end of the
+	// shorter string count as different.
+	Hamming = Metric{"Hamming", hamming, longest}
+)
+
+func (m Metric) String() string { return m.name }
+
+// Distance returns the distance of given strings.
+func (m Metric) Distance(a, b string, normalizers ...func(string) string) int {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	return m.distance(ra, rb, -1)
+}
+
+// DistanceWithin returns the distance of given strings and true if it is at most max.
+// Otherwise the computation stops as soon as the distance is known to exceed max and a
+// distance above max and false are returned.
+func (m Metric) DistanceWithin(a, b string, max int, normalizers ...func(string) string) (int, bool) {
+	if max < 0 {
+		return 0, false
+	}
+	ra, rb := normalizedRunes(a, b, normalizers)
+	d := m.distance(ra, rb, max)
+	if d > max {
+		return max + 1, false
+	}
+	return d, true
+}
+
+// Similarity returns the similarity of given strings between 0 and 1, where 1 means equal
+// strings, computed as 1 - distance / largest possible distance.
+func (m Metric) Similarity(a, b string, normalizers ...func(string) string) float64 {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	return m.similarity(ra, rb)
+}
+
+func (m Metric) similarity(a, b []rune) float64 {
+	maxDistance := m.maxDistance(len(a), len(b))
+	if maxDistance == 0 {
+		return 1
+	}
+	return 1 - float64(m.distance(a, b, -1))/float64(maxDistance)
+}
+
+// LCSLength returns the length in runes of the longest common subsequence of given strings.
+func LCSLength(a, b string, normalizers ...func(string) string) int {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	return lcsLength(ra, rb)
+}
+
+// Jaro returns the Jaro similarity of given strings between 0 and 1, where 1 means equal
+// strings.
+func Jaro(a, b string, normalizers ...func(string) string) float64 {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	return jaro(ra, rb)
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of given strings between 0 and 1, which
+// favours strings with a common prefix of up to 4 runes.
+func JaroWinkler(a, b string, normalizers ...func(string) string) float64 {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	return jaroWinkler(ra, rb)
+}
+
+// JaroWinklerAtLeast returns the Jaro-Winkler similarity of given strings and true if it is at
+// least min. Strings whose lengths are too different to reach min are rejected without
+// comparing them and 0 is returned.
+func JaroWinklerAtLeast(a, b string, min float64, normalizers ...func(string) string) (float64, bool) {
+	ra, rb := normalizedRunes(a, b, normalizers)
+	if jaroWinklerUpperBound(len(ra), len(rb)) < min {
+		return 0, false
+	}
+	s := jaroWinkler(ra, rb)
+	return s, s >= min
+}
+
+func normalizedRunes(a, b string, normalizers []func(string) string) ([]rune, []rune) {
+	for _, normalize := range normalizers {
+		a, b = normalize(a), normalize(b)
+	}
+	return []rune(a), []rune(b)
+}
+
+func levenshtein(a, b []rune, max int) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if max >= 0 && len(a)-len(b) > max {
+		return max + 1
+	}
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+substitutionCost(a[i-1], b[j-1]))
+			rowMin = minInt(rowMin, cur[j])
+		}
+		if max >= 0 && rowMin > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func osa(a, b []rune, max int) int {
+	if max >= 0 && absInt(len(a)-len(b)) > max {
+		return max + 1
+	}
+	prev2, prev, cur := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+substitutionCost(a[i-1], b[j-1]))
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+			rowMin = minInt(rowMin, cur[j])
+		}
+		if max >= 0 && rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// damerauLevenshtein implements the algorithm of Lowrance and Wagner.
+func damerauLevenshtein(a, b []rune, max int) int {
+	if max >= 0 && absInt(len(a)-len(b)) > max {
+		return max + 1
+	}
+	infinity := len(a) + len(b)
+	d := make([][]int, len(a)+2)
+	for i := range d {
+		d[i] = make([]int, len(b)+2)
+		d[i][0] = infinity
+		if i > 0 {
+			d[i][1] = i - 1
+		}
+	}
+	for j := 1; j < len(b)+2; j++ {
+		d[0][j] = infinity
+		d[1][j] = j - 1
+	}
+	lastRow := make(map[rune]int)
+	for i := 1; i <= len(a); i++ {
+		lastCol, rowMin := 0, i
+		for j := 1; j <= len(b); j++ {
+			k, l := lastRow[b[j-1]], lastCol
+			cost := substitutionCost(a[i-1], b[j-1])
+			if cost == 0 {
+				lastCol = j
+			}
+			d[i+1][j+1] = minInt(
+				d[i][j]+cost,
+				d[i+1][j]+1,
+				d[i][j+1]+1,
+				d[k][l]+(i-k-1)+1+(j-l-1),
+			)
+			rowMin = minInt(rowMin, d[i+1][j+1])
+		}
+		if max >= 0 && rowMin > max {
+			return max + 1
+		}
+		lastRow[a[i-1]] = i
+	}
+	return d[len(a)+1][len(b)+1]
+}
+
+func indel(a, b []rune, max int) int {
+	if max >= 0 && absInt(len(a)-len(b)) > max {
+		return max + 1
+	}
+	return len(a) + len(b) - 2*lcsLength(a, b)
+}
+
+func lcsLength(a, b []rune) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = maxInt(prev[j], cur[j-1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func hamming(a, b []rune, max int) int {
+	d := absInt(len(a) - len(b))
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if max >= 0 && d > max {
+			return d
+		}
+		d += substitutionCost(a[i], b[i])
+	}
+	return d
+}
+
+func jaro(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	window := maxInt(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA, matchedB := make([]bool, len(a)), make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := maxInt(0, i-window), minInt(len(b)-1, i+window)
+		for j := lo; j <= hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions/2))/m) / 3
+}
+
+const (
+	jaroWinklerPrefix    = 4
+	jaroWinklerScale     = 0.1
+	jaroWinklerThreshold = 0.7
+)
+
+func jaroWinkler(a, b []rune) float64 {
+	s := jaro(a, b)
+	if s <= jaroWinklerThreshold {
+		return s
+	}
+	prefix := 0
+	for prefix < jaroWinklerPrefix && prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return s + float64(prefix)*jaroWinklerScale*(1-s)
+}
+
+// jaroWinklerUpperBound returns the largest Jaro-Winkler similarity of strings of given
+// lengths.
+func jaroWinklerUpperBound(la, lb int) float64 {
+	if la == 0 || lb == 0 {
+		if la == lb {
+			return 1
+		}
+		return 0
+	}
+	short, long := float64(minInt(la, lb)), float64(maxInt(la, lb))
+	s := (1 + short/long + 1) / 3
+	if s <= jaroWinklerThreshold {
+		return s
+	}
+	return s + float64(minInt(jaroWinklerPrefix, minInt(la, lb)))*jaroWinklerScale*(1-s)
+}
+
+// longest returns the length of the longer string.
+func longest(la, lb int) int { return maxInt(la, lb) }
+
+func substitutionCost(a, b rune) int {
+	if a == b {
+		return 0
+	}
+	return 1
+}
+
+func minInt(n int, ns ...int) int {
+	for _, m := range ns {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
+
+func maxInt(n int, ns ...int) int {
+	for _, m := range ns {
+		if m > n {
+			n = m
+		}
+	}
+	return n
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,105 @@
+package agstring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricDistance(t *testing.T) {
+	testCases := []struct {
+		a, b                                    string
+		levenshtein, osa, damerau, lcs, hamming int
+	}{
+		{"", "", 0, 0, 0, 0, 0},
+		{"", "abc", 3, 3, 3, 3, 3},
+		{"kitten", "sitting", 3, 3, 3, 5, 3},
+		{"ab", "ba", 2, 1, 1, 2, 2},
+		{"ca", "abc", 3, 3, 2, 3, 3},
+		{"karolin", "kathrin", 3, 3, 3, 4, 3},
+		{"café", "cafe", 1, 1, 1, 2, 1},
+		{"東京都", "京都", 1, 1, 1, 1, 3},
+		{"👩‍💻", "👩", 2, 2, 2, 2, 2},
+	}
+
+	for _, testCase := range testCases {
+		a, b := testCase.a, testCase.b
+		require.Equal(t, testCase.levenshtein, Levenshtein.Distance(a, b), "Levenshtein of %q, %q", a, b)
+		require.Equal(t, testCase.osa, OSA.Distance(a, b), "OSA of %q, %q", a, b)
+		require.Equal(t, testCase.damerau, DamerauLevenshtein.Distance(a, b), "Damerau of %q, %q", a, b)
+		require.Equal(t, testCase.lcs, LCS.Distance(a, b), "LCS of %q, %q", a, b)
+		require.Equal(t, testCase.hamming, Hamming.Distance(a, b), "Hamming of %q, %q", a, b)
+		for _, m := range []Metric{Levenshtein, OSA, DamerauLevenshtein, LCS, Hamming} {
+			require.Equal(t, m.Distance(a, b), m.Distance(b, a), "%s is symmetric for %q, %q", m, a, b)
+		}
+	}
+}
+
+func TestMetricDistanceWithin(t *testing.T) {
+	for _, m := range []Metric{Levenshtein, OSA, DamerauLevenshtein, LCS, Hamming} {
+		for _, pair := range [][2]string{{"kitten", "sitting"}, {"ca", "abc"}, {"abcdef", "badcfe"}, {"", "xyz"}} {
+			d := m.Distance(pair[0], pair[1])
+			for max := 0; max <= d+1; max++ {
+				res, ok := m.DistanceWithin(pair[0], pair[1], max)
+				if d <= max {
+					require.True(t, ok, "%s of %q, %q within %d", m, pair[0], pair[1], max)
+					require.Equal(t, d, res)
+				} else {
+					require.False(t, ok, "%s of %q, %q within %d", m, pair[0], pair[1], max)
+					require.Equal(t, max+1, res)
+				}
+			}
+		}
+	}
+	_, ok := Levenshtein.DistanceWithin("a", "a", -1)
+	require.False(t, ok)
+}
+
+func TestMetricSimilarity(t *testing.T) {
+	require.InDelta(t, 1-3.0/7, Levenshtein.Similarity("kitten", "sitting"), 1e-9)
+	require.InDelta(t, 8.0/13, LCS.Similarity("kitten", "sitting"), 1e-9)
+	require.InDelta(t, 0.5, OSA.Similarity("ab", "ba"), 1e-9)
+	require.Equal(t, 1.0, Levenshtein.Similarity("", ""))
+	require.Equal(t, 0.0, Hamming.Similarity("abc", "xyz"))
+	require.Equal(t, 1.0, Levenshtein.Similarity("Café  Noir!", "cafe noir", Normalize))
+	require.Equal(t, 0, DamerauLevenshtein.Distance("ÉCOLE", "ecole", RemoveDiacritics, strings.ToLower))
+	require.Equal(t, "DamerauLevenshtein", DamerauLevenshtein.String())
+}
+
+func TestLCSLength(t *testing.T) {
+	require.Equal(t, 4, LCSLength("kitten", "sitting"))
+	require.Equal(t, 0, LCSLength("", "abc"))
+	require.Equal(t, 2, LCSLength("東京都", "京都"))
+}
+
+func TestJaroWinkler(t *testing.T) {
+	testCases := []struct {
+		a, b              string
+		jaro, jaroWinkler float64
+	}{
+		{"MARTHA", "MARHTA", 0.944444, 0.961111},
+		{"DWAYNE", "DUANE", 0.822222, 0.84},
+		{"DIXON", "DICKSONX", 0.766667, 0.813333},
+		{"abc", "xyz", 0, 0},
+		{"", "", 1, 1},
+		{"", "a", 0, 0},
+		{"çalış", "çalış", 1, 1},
+	}
+
+	for _, testCase := range testCases {
+		require.InDelta(t, testCase.jaro, Jaro(testCase.a, testCase.b), 1e-6, "Jaro of %q, %q", testCase.a, testCase.b)
+		require.InDelta(t, testCase.jaroWinkler, JaroWinkler(testCase.a, testCase.b), 1e-6,
+			"Jaro-Winkler of %q, %q", testCase.a, testCase.b)
+	}
+	require.Equal(t, 1.0, JaroWinkler("Martha", "MARTHA", strings.ToLower))
+
+	s, ok := JaroWinklerAtLeast("MARTHA", "MARHTA", 0.9)
+	require.True(t, ok)
+	require.InDelta(t, 0.961111, s, 1e-6)
+	_, ok = JaroWinklerAtLeast("MARTHA", "MARHTA", 0.99)
+	require.False(t, ok)
+	s, ok = JaroWinklerAtLeast("a", "abcdefghij", 0.9)
+	require.False(t, ok)
+	require.Equal(t, 0.0, s)
+}
@@ -0,0 +1,134 @@
+package agstring
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpaceOptions configures NormalizeSpace.
+type SpaceOptions struct {
+	// KeepParagraphs keeps paragraph breaks, i.e. whitespace holding two or more line breaks
+	// or a paragraph separator, as an empty line instead of collapsing them to a space.
+	KeepParagraphs bool
+	// StripJoiners removes zero width joiners and non-joiners, except the joiners inside emoji
+	// sequences such as "👩‍💻". By default they are kept, as they are meaningful in scripts such
+	// as Persian and Devanagari.
+	StripJoiners bool
+}
+
+func (o SpaceOptions) String() string {
+	return fmt.Sprintf("KeepParagraphs: %t, StripJoiners: %t", o.KeepParagraphs, o.StripJoiners)
+}
+
+const (
+	zwnj = '\u200c'
+	zwj  = '\u200d'
+)
+
+// NormalizeSpace is a Unicode aware variant of ReplaceMultispace. Every run of Unicode white
+// space, such as tabs, line breaks, no-break and ideographic spaces, is replaced with one
+// space, and invisible format characters, such as zero width spaces, soft hyphens and byte
+// order marks, are removed. Space is trimmed from both ends.
+func NormalizeSpace(s string, opts SpaceOptions) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev rune
+	gapStart := -1
+	for i, r := range s {
+		switch {
+		case isSpaceGap(s, i, r, prev, opts):
+			if gapStart < 0 {
+				gapStart = i
+			}
+		case gapStart >= 0:
+			if b.Len() > 0 {
+				b.WriteString(spaceGap(s[gapStart:i], opts))
+			}
+			gapStart = -1
+			fallthrough
+		default:
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// NormalizeSpace appends a NormalizeSpace step with given options.
+func (p *Pipeline) NormalizeSpace(opts SpaceOptions) *Pipeline {
+	return p.Then(stepName("NormalizeSpace", opts.String()), func(s string) string {
+		return NormalizeSpace(s, opts)
+	})
+}
+
+// isSpaceGap checks if r, found at i-th byte of s after prev, is white space or a removable
+// format character.
+func isSpaceGap(s string, i int, r, prev rune, opts SpaceOptions) bool {
+	switch {
+	case unicode.Is(unicode.White_Space, r):
+		return true
+	case r == zwj || r == zwnj:
+		if !opts.StripJoiners {
+			return false
+		}
+		next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+		return r == zwnj || !isEmojiComponent(prev) || !isPictographic(next)
+	case 0xe0020 <= r && r <= 0xe007f:
+		// tags are used in emoji sequences of subdivision flags
+		return false
+	case isPrependedConcatenationMark(r):
+		return false
+	}
+	return unicode.Is(unicode.Cf, r)
+}
+
+// spaceGap returns the replacement of a run of white space and format characters.
+func spaceGap(gap string, opts SpaceOptions) string {
+	breaks, hasSpace := 0, false
+	for i, r := range gap {
+		switch r {
+		case '\u2029':
+			breaks += 2
+		case '\n':
+			if i == 0 || gap[i-1] != '\r' {
+				breaks++
+			}
+		case '\r', '\v', '\f', '\u0085', '\u2028':
+			breaks++
+		}
+		hasSpace = hasSpace || unicode.Is(unicode.White_Space, r)
+	}
+	switch {
+	case opts.KeepParagraphs && breaks >= 2:
+		return "\n\n"
+	case hasSpace:
+		return " "
+	}
+	return ""
+}
+
+func isPrependedConcatenationMark(r rune) bool {
+	return 0x0600 <= r && r <= 0x0605 || r == 0x06dd || r == 0x070f || r == 0x0890 ||
+		r == 0x0891 || r == 0x08e2 || r == 0x110bd || r == 0x110cd
+}
+
+// isPictographic approximates the Extended_Pictographic property of emoji.
+func isPictographic(r rune) bool {
+	switch {
+	case 0x1f000 <= r && r <= 0x1faff, 0x2600 <= r && r <= 0x27bf, 0x2300 <= r && r <= 0x23ff,
+		0x2b00 <= r && r <= 0x2bff, 0x2190 <= r && r <= 0x21ff:
+		return true
+	}
+	switch r {
+	case 0x00a9, 0x00ae, 0x203c, 0x2049, 0x2122, 0x2139, 0x3030, 0x303d, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+// isEmojiComponent checks if r may end an emoji before a joiner.
+func isEmojiComponent(r rune) bool {
+	return isPictographic(r) || r == 0xfe0f || 0xe0020 <= r && r <= 0xe007f
+}
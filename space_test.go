@@ -0,0 +1,56 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSpace(t *testing.T) {
+	testCases := []struct {
+		input    string
+		opts     SpaceOptions
+		expected string
+	}{
+		{"", SpaceOptions{}, ""},
+		{"   a   b   c d    ef  ", SpaceOptions{}, "a b c d ef"},
+		{"\ta\t\tb\n", SpaceOptions{}, "a b"},
+		{"a\u00a0\u00a0b\u202fc\u3000d\u2003e", SpaceOptions{}, "a b c d e"},
+		{"\ufeffword\u200bjoiner\u00ad", SpaceOptions{}, "wordjoiner"},
+		{"a \u200b b", SpaceOptions{}, "a b"},
+		{"a\u2028b\u2029c\u0085d", SpaceOptions{}, "a b c d"},
+		{"first\r\n\r\nsecond\nthird", SpaceOptions{}, "first second third"},
+		{"first\r\n\r\nsecond\nthird", SpaceOptions{KeepParagraphs: true}, "first\n\nsecond third"},
+		{"first \n \t\n second", SpaceOptions{KeepParagraphs: true}, "first\n\nsecond"},
+		{"first\u2029second\n\n", SpaceOptions{KeepParagraphs: true}, "first\n\nsecond"},
+		{"\n\nfirst", SpaceOptions{KeepParagraphs: true}, "first"},
+		{"می\u200cخواهم", SpaceOptions{}, "می\u200cخواهم"},
+		{"می\u200cخواهم", SpaceOptions{StripJoiners: true}, "میخواهم"},
+		{"a\u200db", SpaceOptions{StripJoiners: true}, "ab"},
+		{"👩\u200d💻 coder", SpaceOptions{StripJoiners: true}, "👩\u200d💻 coder"},
+		{"❤️\u200d🔥", SpaceOptions{StripJoiners: true}, "❤️\u200d🔥"},
+		{"👩\u200d \u200d💻", SpaceOptions{StripJoiners: true}, "👩 💻"},
+		{"🏴\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f", SpaceOptions{},
+			"🏴\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f"},
+		{"\u0600١٢", SpaceOptions{}, "\u0600١٢"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, NormalizeSpace(testCase.input, testCase.opts),
+			"for %q with %s", testCase.input, testCase.opts)
+	}
+}
+
+func TestNormalizeSpaceMatchesReplaceMultispace(t *testing.T) {
+	for _, input := range []string{"   a   b   c d    ef  ", "     123   ", "a  b"} {
+		require.Equal(t, ReplaceMultispace(input), NormalizeSpace(input, SpaceOptions{}))
+	}
+
+	p := NewPipeline().NormalizeSpace(SpaceOptions{KeepParagraphs: true})
+	require.Equal(t, `NormalizeSpace("KeepParagraphs: true, StripJoiners: false")`, p.String())
+	require.Equal(t, "a b\n\nc", p.Apply(" a\u00a0b\n\n c "))
+
+	p, err := ParseRules([]byte("steps: [normalize_space]"))
+	require.NoError(t, err)
+	require.Equal(t, "a b", p.Apply("a\u00a0\u200bb"))
+}
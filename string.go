@@ -1,7 +1,6 @@
 package agstring
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -39,30 +38,24 @@ func TrimSuffixes(s string, suffixes ...string) string {
 }
 
 // TrimPrefixesAndSpace returns a string without any of the provided leading prefixes at word
-// boundaries or spaces. See test for examples.
+// boundaries or spaces. Prefixes are matched literally. See PrefixTrimmer and test for examples.
 func TrimPrefixesAndSpace(s string, prefixes []string) string {
 	if prefixes == nil || s == "" {
 		return s
 	}
-	trimAgain := true
-	rePre := make([]*regexp.Regexp, len(prefixes))
-	for trimAgain {
-		trimAgain = false
-		for i, prefix := range prefixes {
-			if prefix == "" {
-				continue
-			}
-			if rePre[i] == nil {
-				reE := fmt.Sprintf("^\\s*%s\\b(?P<rest>.*)", prefix)
-				rePre[i] = regexp.MustCompile(reE)
-			}
-			if matches, ok := RegexpGroups(rePre[i], strings.TrimSpace(s)); ok {
-				s = matches["rest"]
-				trimAgain = true
+	s = strings.TrimSpace(s)
+	for {
+		cut := 0
+		for _, prefix := range prefixes {
+			if len(prefix) > cut && strings.HasPrefix(s, prefix) && isWordBoundary(s, len(prefix)) {
+				cut = len(prefix)
 			}
 		}
+		if cut == 0 {
+			return s
+		}
+		s = strings.TrimSpace(s[cut:])
 	}
-	return strings.TrimSpace(s)
 }
 
 var nonAlphanumRegexp = regexp.MustCompile("[^[:alnum:]]")
@@ -82,8 +75,12 @@ func ContainsAll(holder, searched []string) bool {
 	return true
 }
 
-// StringContainsAll checks if given string contains all searched strings
-func StringContainsAll(holder string, searched []string) bool {
+// StringContainsAll checks if given string contains all searched strings. When a matcher is
+// given, its patterns are searched instead of the searched strings, which may then be nil.
+func StringContainsAll(holder string, searched []string, matcher ...*Matcher) bool {
+	if len(matcher) > 0 && matcher[0] != nil {
+		return matcher[0].ContainsAll(holder)
+	}
 	for _, s := range searched {
 		if !strings.Contains(holder, s) {
 			return false
@@ -102,8 +99,12 @@ func ContainsAny(src, qs []string) bool {
 	return false
 }
 
-// StringContainsAny is similar to ContainsAny but source is a string
-func StringContainsAny(s string, ls []string) bool {
+// StringContainsAny is similar to ContainsAny but source is a string. When a matcher is given,
+// its patterns are searched instead of the strings of the list, which may then be nil.
+func StringContainsAny(s string, ls []string, matcher ...*Matcher) bool {
+	if len(matcher) > 0 && matcher[0] != nil {
+		return matcher[0].ContainsAny(s)
+	}
 	for _, e := range ls {
 		if strings.Contains(s, e) {
 			return true
@@ -112,8 +113,12 @@ func StringContainsAny(s string, ls []string) bool {
 	return false
 }
 
-// MatchesPrefixes checks if given string has a prefix from given prefix list
-func MatchesPrefixes(s string, prefixes []string) bool {
+// MatchesPrefixes checks if given string has a prefix from given prefix list. When a prefix
+// set is given, it is searched instead of the list, which may then be nil.
+func MatchesPrefixes(s string, prefixes []string, set ...*PrefixSet) bool {
+	if len(set) > 0 && set[0] != nil {
+		return set[0].HasPrefix(s)
+	}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(s, prefix) {
 			return true
@@ -160,7 +165,8 @@ func ValueIfExists(k string, m map[string]string) string {
 	return k
 }
 
-// ReplaceWholeWord replaces old into new only if old occurs as a whole word.
+// ReplaceWholeWord replaces old into new only if old occurs as a whole word, i.e. surrounded by
+// spaces. See WordReplacer to use Unicode word boundaries or replace many words at once.
 func ReplaceWholeWord(s, old, replacement string) string {
 	s = " " + s + " "
 	old = " " + old + " "
@@ -192,7 +198,25 @@ func ToLower(ls []string) []string {
 }
 
 // Title ensures title formatting for given string
-func Title(s string) string { return strings.Title(strings.ToLower(s)) }
+// See KeepRomanNumerals for options.
+func Title(s string, opts ...TitleOption) string {
+	var o titleOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.keepRoman {
+		return strings.Title(strings.ToLower(s))
+	}
+	var b strings.Builder
+	last := 0
+	for _, r := range titleNumerals(s) {
+		b.WriteString(strings.Title(strings.ToLower(s[last:r.Start])))
+		b.WriteString(strings.ToUpper(s[r.Start:r.End]))
+		last = r.End
+	}
+	b.WriteString(strings.Title(strings.ToLower(s[last:])))
+	return b.String()
+}
 
 // HasPrefix checks string has any one of given prefixes
 func HasPrefix(s string, prefixes ...string) bool {
@@ -288,15 +312,17 @@ func min(a, b int) int {
 	return b
 }
 
-// ReplaceDayOrdinal replaces day ordinals (`st`, `nd`, `rd`, `th`)
-// Default replaces with empty string.
+// ReplaceDayOrdinal replaces day ordinals (`st`, `nd`, `rd`, `th`) following digits, so
+// "1st Thursday" becomes "1 Thursday". Default replaces with empty string. See
+// StripOrdinalSuffixes for other languages.
 func ReplaceDayOrdinal(s string, replacements ...string) string {
 	var rep string
 	if len(replacements) > 0 {
 		rep = replacements[0]
 	}
-	ordinal := strings.NewReplacer("st", rep, "nd", rep, "th", rep, "rd", rep)
-	return ordinal.Replace(s)
+	return replaceOrdinals(s, findNumericOrdinals(s, "en", false), func(_ Ordinal, text string) string {
+		return leadingDigits(text) + rep
+	})
 }
 
 // ReplaceNewline replaces the newline character `\n`
@@ -265,6 +265,7 @@ func TestTrimPrefixesAndSpace(t *testing.T) {
 		{" cif fob massive port", []string{"big", "location"}, "cif fob massive port"},
 		{" cif fob massive port", []string{""}, "cif fob massive port"},
 		{" cif fob", []string{"fob", "cif"}, ""},
+		{"Dr. dr Smith", []string{"Dr", "Dr.", "dr"}, "Smith"},
 		{" ", []string{}, ""},
 		{"", nil, ""},
 	}
@@ -0,0 +1,230 @@
+package agstring
+
+import (
+	"sort"
+	"strings"
+)
+
+// Ratio returns the similarity of given strings between 0 and 100, based on the number of
+// insertions and deletions turning one into the other. It is the LCS similarity in percents.
+func Ratio(a, b string, normalizers ...func(string) string) float64 {
+	return 100 * LCS.Similarity(a, b, normalizers...)
+}
+
+// Tokens splits given string into words cleaned with Normalize. Noise words, such as the legal
+// forms of company names, are removed from both ends of the string unless nothing else would
+// remain. For example "Acme Holdings Ltd." becomes "acme", "holdings" with noise word "ltd".
+func Tokens(s string, noise ...string) []string {
+	tokens := normalizedTokens(s)
+	if len(tokens) == 0 || len(noise) == 0 {
+		return tokens
+	}
+	return strings.Fields(trimNoise(strings.Join(tokens, " "), noise))
+}
+
+func normalizedTokens(s string) []string {
+	var tokens []string
+	for _, w := range strings.Split(ReplaceMultispace(s), " ") {
+		if w = Normalize(w); w != "" {
+			tokens = append(tokens, w)
+		}
+	}
+	return tokens
+}
+
+// trimNoise removes given noise words from both ends of the normalized string s.
+func trimNoise(s string, noise []string) string {
+	var prefixes, suffixes []string
+	for _, n := range noise {
+		if n = strings.Join(normalizedTokens(n), " "); n != "" {
+			prefixes, suffixes = append(prefixes, n), append(suffixes, " "+n)
+		}
+	}
+	for {
+		trimmed := TrimPrefixesAndSpace(TrimSuffixes(s, suffixes...), prefixes)
+		if trimmed == "" || trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+// PartialRatio returns the Ratio of the tokens of the shorter string and the best matching
+// substring of the tokens of the longer one, so that "Acme" and "Acme Holdings" score 100.
+// Strings without tokens score 0.
+func PartialRatio(a, b string, noise ...string) float64 {
+	return partialRatio(Tokens(a, noise...), Tokens(b, noise...))
+}
+
+// TokenSortRatio returns the Ratio of the sorted tokens of given strings, so that the order of
+// words doesn't matter. Strings without tokens score 0.
+func TokenSortRatio(a, b string, noise ...string) float64 {
+	return tokenSortRatio(Tokens(a, noise...), Tokens(b, noise...))
+}
+
+// TokenSetRatio compares the common tokens of given strings with the common tokens followed
+// by the remaining tokens of each string, so that neither the order nor the repetition of
+// words matters, and a string whose tokens are all found in the other scores 100. Strings
+// without tokens score 0.
+func TokenSetRatio(a, b string, noise ...string) float64 {
+	return tokenSetRatio(Tokens(a, noise...), Tokens(b, noise...))
+}
+
+// WeightedRatio returns the best of the Ratio, PartialRatio, TokenSortRatio and TokenSetRatio
+// of given strings, weighted by how reliable each is for strings of such lengths, as the WRatio
+// of fuzzywuzzy does. Strings without tokens score 0.
+func WeightedRatio(a, b string, noise ...string) float64 {
+	return weightedRatio(Tokens(a, noise...), Tokens(b, noise...))
+}
+
+// ScoredChoice is a choice scored by ExtractBest.
+type ScoredChoice struct {
+	// Choice is the scored choice.
+	Choice string
+	// Index is the index of the choice in the list of choices.
+	Index int
+	// Score is the WeightedRatio of the choice and the query.
+	Score float64
+}
+
+// ExtractBest scores given choices against the query with WeightedRatio and returns up to
+// limit choices scoring at least cutoff, best first. Choices with equal scores keep their
+// order. A limit below 1 returns every choice reaching the cutoff.
+func ExtractBest(query string, choices []string, limit int, cutoff float64, noise ...string) []ScoredChoice {
+	queryTokens := Tokens(query, noise...)
+	var scored []ScoredChoice
+	for i, choice := range choices {
+		score := weightedRatio(queryTokens, Tokens(choice, noise...))
+		if score >= cutoff {
+			scored = append(scored, ScoredChoice{Choice: choice, Index: i, Score: score})
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+func partialRatio(ta, tb []string) float64 {
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	short, long := []rune(strings.Join(ta, " ")), []rune(strings.Join(tb, " "))
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	best := 0.0
+	// windows sliding over the ends of the longer string are shorter than the shorter string
+	for start := 1 - len(short); start < len(long); start++ {
+		window := long[maxInt(start, 0):minInt(start+len(short), len(long))]
+		if score := 100 * LCS.similarity(short, window); score > best {
+			best = score
+			if best == 100 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+func tokenSortRatio(ta, tb []string) float64 {
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	return Ratio(strings.Join(sortedTokens(ta), " "), strings.Join(sortedTokens(tb), " "))
+}
+
+func tokenSetRatio(ta, tb []string) float64 {
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	setA, setB := make(map[string]bool), make(map[string]bool)
+	for _, t := range ta {
+		setA[t] = true
+	}
+	for _, t := range tb {
+		setB[t] = true
+	}
+	var common, onlyA, onlyB []string
+	for t := range setA {
+		if setB[t] {
+			common = append(common, t)
+		} else {
+			onlyA = append(onlyA, t)
+		}
+	}
+	for t := range setB {
+		if !setA[t] {
+			onlyB = append(onlyB, t)
+		}
+	}
+	if len(common) > 0 && (len(onlyA) == 0 || len(onlyB) == 0) {
+		return 100
+	}
+	sort.Strings(common)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	joined := strings.Join(common, " ")
+	withA := strings.TrimSpace(joined + " " + strings.Join(onlyA, " "))
+	withB := strings.TrimSpace(joined + " " + strings.Join(onlyB, " "))
+	best := Ratio(withA, withB)
+	if joined != "" {
+		best = maxFloat(best, Ratio(joined, withA), Ratio(joined, withB))
+	}
+	return best
+}
+
+// Weights of the ratios of WeightedRatio
+const (
+	tokenRatioScale       = 0.95
+	partialRatioScale     = 0.9
+	longPartialRatioScale = 0.6
+)
+
+func weightedRatio(ta, tb []string) float64 {
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	a, b := strings.Join(ta, " "), strings.Join(tb, " ")
+	ratio := Ratio(a, b)
+	la, lb := float64(len([]rune(a))), float64(len([]rune(b)))
+	lengthRatio := maxFloat(la, lb) / maxFloat(minFloat(la, lb), 1)
+	if lengthRatio < 1.5 {
+		return maxFloat(ratio,
+			tokenRatioScale*tokenSortRatio(ta, tb),
+			tokenRatioScale*tokenSetRatio(ta, tb))
+	}
+	scale := partialRatioScale
+	if lengthRatio >= 8 {
+		scale = longPartialRatioScale
+	}
+	return maxFloat(ratio,
+		scale*partialRatio(ta, tb),
+		scale*tokenRatioScale*tokenSortRatio(ta, tb),
+		scale*tokenRatioScale*tokenSetRatio(ta, tb))
+}
+
+func sortedTokens(tokens []string) []string {
+	sorted := append([]string(nil), tokens...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func minFloat(f float64, fs ...float64) float64 {
+	for _, g := range fs {
+		if g < f {
+			f = g
+		}
+	}
+	return f
+}
+
+func maxFloat(f float64, fs ...float64) float64 {
+	for _, g := range fs {
+		if g > f {
+			f = g
+		}
+	}
+	return f
+}
@@ -0,0 +1,83 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokens(t *testing.T) {
+	testCases := []struct {
+		input    string
+		noise    []string
+		expected []string
+	}{
+		{"", nil, nil},
+		{"  Acme   Holdings Ltd. ", nil, []string{"acme", "holdings", "ltd"}},
+		{"Acme Holdings Ltd.", []string{"Ltd."}, []string{"acme", "holdings"}},
+		{"Ltd. ACME", []string{"ltd"}, []string{"acme"}},
+		{"Acme Co Pty Ltd", []string{"pty ltd", "co"}, []string{"acme"}},
+		{"Ltd", []string{"ltd"}, []string{"ltd"}},
+		{"Ltdx Acme", []string{"ltd"}, []string{"ltdx", "acme"}},
+		{"Café Noir & Co", nil, []string{"cafe", "noir", "co"}},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Tokens(testCase.input, testCase.noise...), "for %q", testCase.input)
+	}
+}
+
+func TestRatios(t *testing.T) {
+	testCases := []struct {
+		a, b                         string
+		noise                        []string
+		partial, tokenSort, tokenSet float64
+	}{
+		{"Acme Holdings Ltd", "Ltd. ACME", nil, 66.666667, 64, 100},
+		{"Acme Holdings Ltd", "Ltd. ACME", []string{"ltd"}, 100, 47.058824, 100},
+		{"new york mets", "new YORK Mets!", nil, 100, 100, 100},
+		{"mets new york", "new york mets vs atlanta braves", nil, 76.190476, 59.090909, 100},
+		{"Acme", "Acme Holdings", nil, 100, 47.058824, 100},
+		{"fuzzy", "wuzzy", nil, 88.888889, 80, 80},
+		{"", "acme", nil, 0, 0, 0},
+		{"!!", "!!", nil, 0, 0, 0},
+	}
+
+	for _, testCase := range testCases {
+		a, b, noise := testCase.a, testCase.b, testCase.noise
+		require.InDelta(t, testCase.partial, PartialRatio(a, b, noise...), 1e-6, "partial for %q, %q", a, b)
+		require.InDelta(t, testCase.tokenSort, TokenSortRatio(a, b, noise...), 1e-6, "token sort for %q, %q", a, b)
+		require.InDelta(t, testCase.tokenSet, TokenSetRatio(a, b, noise...), 1e-6, "token set for %q, %q", a, b)
+		require.Equal(t, TokenSetRatio(a, b, noise...), TokenSetRatio(b, a, noise...))
+	}
+}
+
+func TestRatio(t *testing.T) {
+	require.InDelta(t, 100*8.0/13, Ratio("kitten", "sitting"), 1e-9)
+	require.Equal(t, 100.0, Ratio("", ""))
+	require.Equal(t, 100.0, Ratio("Straße", "strasse", RemoveDiacritics, Normalize))
+}
+
+func TestWeightedRatio(t *testing.T) {
+	require.Equal(t, 100.0, WeightedRatio("Acme Ltd", "ACME LTD."))
+	require.InDelta(t, 95, WeightedRatio("Acme Holdings", "Holdings Acme"), 1e-9)
+	require.InDelta(t, 90, WeightedRatio("Acme", "Acme Holdings Ltd"), 1e-9)
+	require.Equal(t, 0.0, WeightedRatio("Acme", ""))
+}
+
+func TestExtractBest(t *testing.T) {
+	choices := []string{"Apex Inc", "ACME Holdings Ltd", "Acne", "Acme", "acme"}
+
+	res := ExtractBest("Acme", choices, 2, 50)
+	require.Equal(t, []ScoredChoice{{"Acme", 3, 100}, {"acme", 4, 100}}, res)
+
+	res = ExtractBest("Acme", choices, 0, 80)
+	require.Len(t, res, 3)
+	require.Equal(t, "ACME Holdings Ltd", res[2].Choice)
+	require.InDelta(t, 90, res[2].Score, 1e-9)
+
+	res = ExtractBest("Ltd ACME", choices, 1, 0, "ltd")
+	require.Equal(t, 3, res[0].Index)
+	require.Empty(t, ExtractBest("Acme", nil, 3, 0))
+	require.Empty(t, ExtractBest("Acme", choices, 3, 101))
+}
@@ -0,0 +1,251 @@
+package agstring
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// The transformers below are streaming counterparts of the string cleaners. They can be used
+// with transform.NewReader and transform.NewWriter to clean large inputs without loading them
+// into memory, and give the same results as the string functions regardless of how the input
+// is split into chunks.
+
+// runeTransformer replaces every rune with the result of a function of it.
+type runeTransformer struct {
+	transform.NopResetter
+	fn func(rune) string
+}
+
+func (t runeTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		out := t.fn(r)
+		if nDst+len(out) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], out)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// bufferedTransformer consumes all of its input, keeping the output that doesn't fit into the
+// destination until the next call.
+type bufferedTransformer struct {
+	out []byte
+}
+
+// flush writes as much of the kept output as fits into dst.
+func (t *bufferedTransformer) flush(dst []byte) (int, error) {
+	n := copy(dst, t.out)
+	if n < len(t.out) {
+		t.out = t.out[n:]
+		return n, transform.ErrShortDst
+	}
+	t.out = t.out[:0]
+	return n, nil
+}
+
+// transformWith consumes the complete runes of src with process and flushes the output.
+func (t *bufferedTransformer) transformWith(dst, src []byte, atEOF bool,
+	process func(src []byte, atEOF bool)) (nDst, nSrc int, err error) {
+	if nDst, err = t.flush(dst); err != nil {
+		return nDst, 0, err
+	}
+	nSrc = len(src)
+	if !atEOF {
+		nSrc = completeRunes(src)
+	}
+	process(src[:nSrc], atEOF)
+	n, err := t.flush(dst[nDst:])
+	if err == nil && nSrc < len(src) {
+		err = transform.ErrShortSrc
+	}
+	return nDst + n, nSrc, err
+}
+
+// completeRunes returns the length of the prefix of b holding no truncated rune at its end.
+func completeRunes(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+			return i
+		}
+	}
+	return len(b)
+}
+
+type multispaceTransformer struct {
+	bufferedTransformer
+	started bool
+	space   []byte
+}
+
+// ReplaceMultispaceTransformer returns a transformer replacing multiple spaces with one space
+// and trimming space from both ends, like ReplaceMultispace does.
+func ReplaceMultispaceTransformer() transform.Transformer {
+	return &multispaceTransformer{}
+}
+
+func (t *multispaceTransformer) Reset() {
+	*t = multispaceTransformer{}
+}
+
+func (t *multispaceTransformer) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	return t.transformWith(dst, src, atEOF, t.process)
+}
+
+func (t *multispaceTransformer) process(src []byte, atEOF bool) {
+	for len(src) > 0 {
+		r, size := utf8.DecodeRune(src)
+		switch {
+		case !unicode.IsSpace(r):
+			t.out = append(t.out, t.space...)
+			t.out = append(t.out, src[:size]...)
+			t.space = t.space[:0]
+			t.started = true
+		case !t.started:
+		case r == ' ' && len(t.space) > 0 && t.space[len(t.space)-1] == ' ':
+		default:
+			t.space = append(t.space, src[:size]...)
+		}
+		src = src[size:]
+	}
+}
+
+// RemoveDiacriticsTransformer returns a transformer removing diacritics like RemoveDiacritics
+// does.
+func RemoveDiacriticsTransformer() transform.Transformer {
+	return runeTransformer{fn: unidecodeRune}
+}
+
+// RemoveNonAlnumTransformer returns a transformer removing non-alphanumeric characters like
+// RemoveNonAlnum does.
+func RemoveNonAlnumTransformer() transform.Transformer {
+	return runeTransformer{fn: func(r rune) string {
+		if '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+			return string(r)
+		}
+		return ""
+	}}
+}
+
+// NormalizeTransformer returns a transformer normalizing like Normalize does.
+func NormalizeTransformer() transform.Transformer {
+	return runeTransformer{fn: normalizeRune}
+}
+
+// normalizeRune normalizes a single rune like Normalize does.
+func normalizeRune(r rune) string {
+	return strings.ToLower(RemoveNonAlnum(unidecodeRune(r)))
+}
+
+type newlineTransformer struct {
+	transform.NopResetter
+	rep []byte
+}
+
+// ReplaceNewlineTransformer returns a transformer replacing newlines like ReplaceNewline does.
+func ReplaceNewlineTransformer(replacements ...string) transform.Transformer {
+	var rep string
+	if len(replacements) > 0 {
+		rep = replacements[0]
+	}
+	return newlineTransformer{rep: []byte(rep)}
+}
+
+func (t newlineTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		out := src[nSrc : nSrc+1]
+		if src[nSrc] == '\n' {
+			out = t.rep
+		}
+		if nDst+len(out) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], out)
+		nSrc++
+	}
+	return nDst, nSrc, nil
+}
+
+type wholeWordTransformer struct {
+	bufferedTransformer
+	old, replacement []byte
+	started, trimmed bool
+	done             bool
+	tail             []byte
+}
+
+// ReplaceWholeWordTransformer returns a transformer replacing old into new only if old occurs
+// as a whole word, like ReplaceWholeWord does.
+func ReplaceWholeWordTransformer(old, replacement string) transform.Transformer {
+	return &wholeWordTransformer{
+		old:         []byte(" " + old + " "),
+		replacement: []byte(" " + replacement + " "),
+	}
+}
+
+func (t *wholeWordTransformer) Reset() {
+	t.out, t.tail = t.out[:0], t.tail[:0]
+	t.started, t.trimmed, t.done = false, false, false
+}
+
+func (t *wholeWordTransformer) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	return t.transformWith(dst, src, atEOF, t.process)
+}
+
+// process works like ReplaceWholeWord: the input is surrounded with spaces, replaced and the
+// first and last bytes of the result are dropped.
+func (t *wholeWordTransformer) process(src []byte, atEOF bool) {
+	if t.done {
+		return
+	}
+	if !t.started {
+		t.tail = append(t.tail, ' ')
+		t.started = true
+	}
+	t.tail = append(t.tail, src...)
+	if atEOF {
+		t.tail = append(t.tail, ' ')
+	}
+	buf := t.tail
+	for {
+		i := bytes.Index(buf, t.old)
+		if i < 0 {
+			break
+		}
+		t.emit(buf[:i])
+		t.emit(t.replacement)
+		buf = buf[i+len(t.old):]
+	}
+	if atEOF {
+		t.emit(buf)
+		t.out = t.out[:len(t.out)-1]
+		t.tail = t.tail[:0]
+		t.done = true
+		return
+	}
+	keep := len(t.old) - 1
+	if len(buf) > keep {
+		t.emit(buf[:len(buf)-keep])
+		buf = buf[len(buf)-keep:]
+	}
+	t.tail = append(t.tail[:0], buf...)
+}
+
+func (t *wholeWordTransformer) emit(b []byte) {
+	if !t.trimmed && len(b) > 0 {
+		b, t.trimmed = b[1:], true
+	}
+	t.out = append(t.out, b...)
+}
@@ -0,0 +1,117 @@
+package agstring
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/transform"
+)
+
+// transformChunked runs given transformer reading the input one byte at a time.
+func transformChunked(t transform.Transformer, s string) (string, error) {
+	out, err := ioutil.ReadAll(transform.NewReader(iotest.OneByteReader(strings.NewReader(s)), t))
+	return string(out), err
+}
+
+// transformWriter runs given transformer writing to a writer.
+func transformWriter(t transform.Transformer, s string) (string, error) {
+	var buf bytes.Buffer
+	w := transform.NewWriter(&buf, t)
+	for _, b := range []byte(s) {
+		if _, err := w.Write([]byte{b}); err != nil {
+			return "", err
+		}
+	}
+	err := w.Close()
+	return buf.String(), err
+}
+
+func TestTransformers(t *testing.T) {
+	inputs := []string{
+		"",
+		" ",
+		"   a   b   c d    ef  ",
+		"\t Ağ  \n Flow\t",
+		"port st jose",
+		"st st st",
+		"st",
+		"Hi\nThere\n\n",
+		"ąćęłńóśźż ĄĆĘŁŃÓŚŹŻ ドンキーコング",
+		"Straße, Łódź & Ağ-Flow!",
+		"invalid \xff utf8 \xe2\x82",
+	}
+	testCases := []struct {
+		name        string
+		transformer func() transform.Transformer
+		fn          func(string) string
+	}{
+		{"ReplaceMultispace", ReplaceMultispaceTransformer, ReplaceMultispace},
+		{"RemoveDiacritics", RemoveDiacriticsTransformer, RemoveDiacritics},
+		{"RemoveNonAlnum", RemoveNonAlnumTransformer, RemoveNonAlnum},
+		{"Normalize", NormalizeTransformer, Normalize},
+		{
+			"ReplaceNewline",
+			func() transform.Transformer { return ReplaceNewlineTransformer(" | ") },
+			func(s string) string { return ReplaceNewline(s, " | ") },
+		},
+		{
+			"ReplaceWholeWord",
+			func() transform.Transformer { return ReplaceWholeWordTransformer("st", "saint") },
+			func(s string) string { return ReplaceWholeWord(s, "st", "saint") },
+		},
+		{
+			"ReplaceWholeWordShrinking",
+			func() transform.Transformer { return ReplaceWholeWordTransformer("port st", "") },
+			func(s string) string { return ReplaceWholeWord(s, "port st", "") },
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, input := range inputs {
+			expected := testCase.fn(input)
+
+			res, _, err := transform.String(testCase.transformer(), input)
+			require.NoError(t, err)
+			require.Equal(t, expected, res, "%s of %q", testCase.name, input)
+
+			res, err = transformChunked(testCase.transformer(), input)
+			require.NoError(t, err)
+			require.Equal(t, expected, res, "%s of %q read in chunks", testCase.name, input)
+
+			res, err = transformWriter(testCase.transformer(), input)
+			require.NoError(t, err)
+			require.Equal(t, expected, res, "%s of %q written in chunks", testCase.name, input)
+		}
+	}
+}
+
+func TestTransformerReset(t *testing.T) {
+	tr := ReplaceWholeWordTransformer("st", "saint")
+	res, _, err := transform.String(tr, "port st jose")
+	require.NoError(t, err)
+	require.Equal(t, "port saint jose", res)
+	res, _, err = transform.String(tr, "st louis")
+	require.NoError(t, err)
+	require.Equal(t, "saint louis", res)
+}
+
+func TestTransformerShortDst(t *testing.T) {
+	tr := ReplaceWholeWordTransformer("st", "saint")
+	dst := make([]byte, 4)
+	var out []byte
+	src := []byte("st a st")
+	for {
+		nDst, nSrc, err := tr.Transform(dst, src, true)
+		out = append(out, dst[:nDst]...)
+		src = src[nSrc:]
+		if err == nil {
+			break
+		}
+		require.Equal(t, transform.ErrShortDst, err)
+	}
+	require.Equal(t, "saint a saint", string(out))
+}
@@ -0,0 +1,257 @@
+package agstring
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mozillazg/go-unidecode/table"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// TransliterationProfile holds language specific transliterations overriding the base
+// unidecode table used by RemoveDiacritics.
+//
+// Only lower case runes need to be listed; upper case runes are derived from them. A derived
+// replacement is written in upper case when the neighbouring letters are upper case ("MÜLLER"
+// becomes "MUELLER") and with an upper case initial otherwise ("Müller" becomes "Mueller").
+type TransliterationProfile struct {
+	// Base names a registered profile whose transliterations are inherited.
+	Base string
+	// Runes maps runes to their transliterations.
+	Runes map[rune]string
+	// Initial maps runes to the transliterations used at the beginning of words, e.g. Ukrainian
+	// "я" is "ia" inside a word but "ya" at its beginning.
+	Initial map[rune]string
+}
+
+// Transliterator transliterates strings with a registered profile. It is safe for concurrent
+// use.
+type Transliterator struct {
+	name    string
+	runes   map[rune]string
+	initial map[rune]string
+}
+
+var (
+	transliteratorsMu sync.RWMutex
+	transliterators   = make(map[string]*Transliterator)
+)
+
+var builtinTransliterations = []struct {
+	name    string
+	profile TransliterationProfile
+}{
+	{"de", TransliterationProfile{Runes: map[rune]string{
+		'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss", 'ẞ': "SS",
+	}}},
+	{"da", TransliterationProfile{Runes: map[rune]string{
+		'æ': "ae", 'ø': "oe", 'å': "aa",
+	}}},
+	{"nb", TransliterationProfile{Base: "da"}},
+	{"no", TransliterationProfile{Base: "da"}},
+	{"sv", TransliterationProfile{Runes: map[rune]string{
+		'ä': "ae", 'ö': "oe", 'å': "aa", 'æ': "ae", 'ø': "oe",
+	}}},
+	{"tr", TransliterationProfile{Runes: map[rune]string{
+		'ı': "i", 'İ': "I", 'ğ': "g", 'ş': "s", 'ç': "c", 'ö': "o", 'ü': "u",
+	}}},
+	{"az", TransliterationProfile{Base: "tr", Runes: map[rune]string{'ə': "e"}}},
+	{"ru-iso9", TransliterationProfile{Runes: map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë", 'ж': "ž",
+		'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+		'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "c",
+		'ч': "č", 'ш': "š", 'щ': "ŝ", 'ъ': "ʺ", 'ы': "y", 'ь': "ʹ", 'э': "è", 'ю': "û",
+		'я': "â",
+	}}},
+	{"ru-bgn", TransliterationProfile{
+		Runes: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë", 'ж': "zh",
+			'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+			'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+			'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ˮ", 'ы': "y", 'ь': "ʼ", 'э': "e",
+			'ю': "yu", 'я': "ya",
+		},
+		Initial: map[rune]string{'е': "ye", 'ё': "yë"},
+	}},
+	{"ru", TransliterationProfile{Base: "ru-bgn"}},
+	{"uk-national", TransliterationProfile{
+		Runes: map[rune]string{
+			'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie",
+			'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k", 'л': "l",
+			'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+			'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ь': "",
+			'ю': "iu", 'я': "ia", '\'': "", '’': "", 'ʼ': "",
+		},
+		Initial: map[rune]string{'є': "ye", 'ї': "yi", 'й': "y", 'ю': "yu", 'я': "ya"},
+	}},
+	{"uk", TransliterationProfile{Base: "uk-national"}},
+}
+
+func init() {
+	for _, t := range builtinTransliterations {
+		if err := RegisterTransliteration(t.name, t.profile); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterTransliteration registers a transliteration profile under given name, replacing
+// any profile registered with the same name.
+func RegisterTransliteration(name string, profile TransliterationProfile) error {
+	t := &Transliterator{
+		name:    name,
+		runes:   make(map[rune]string),
+		initial: make(map[rune]string),
+	}
+	if profile.Base != "" {
+		base, err := NewTransliterator(profile.Base)
+		if err != nil {
+			return errors.Wrapf(err, "can't register transliteration %q", name)
+		}
+		copyTransliterations(t.runes, base.runes)
+		copyTransliterations(t.initial, base.initial)
+	}
+	copyTransliterations(t.runes, deriveUpper(profile.Runes))
+	copyTransliterations(t.initial, deriveUpper(profile.Initial))
+
+	transliteratorsMu.Lock()
+	defer transliteratorsMu.Unlock()
+	transliterators[name] = t
+	return nil
+}
+
+// NewTransliterator returns a transliterator for the profile registered with given name.
+func NewTransliterator(profile string) (*Transliterator, error) {
+	transliteratorsMu.RLock()
+	defer transliteratorsMu.RUnlock()
+	t, ok := transliterators[profile]
+	if !ok {
+		return nil, errors.Errorf("unknown transliteration profile %q", profile)
+	}
+	return t, nil
+}
+
+// TransliterationProfiles returns the names of the registered transliteration profiles.
+func TransliterationProfiles() []string {
+	transliteratorsMu.RLock()
+	defer transliteratorsMu.RUnlock()
+	names := make([]string, 0, len(transliterators))
+	for name := range transliterators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Transliterate transliterates given string like RemoveDiacritics does, using the overrides of
+// given profile, e.g. German "Müller" becomes "Mueller" with the "de" profile. Note that some
+// profiles, such as "ru-iso9", transliterate to Latin letters with diacritics.
+func Transliterate(s, profile string) (string, error) {
+	t, err := NewTransliterator(profile)
+	if err != nil {
+		return "", err
+	}
+	return t.Transliterate(s), nil
+}
+
+// Name returns the name of the profile of the transliterator.
+func (t *Transliterator) Name() string { return t.name }
+
+// Transliterate transliterates given string with the profile of the transliterator.
+func (t *Transliterator) Transliterate(s string) string {
+	var b strings.Builder
+	t.transliterate(s, func(_, _ int, out string) { b.WriteString(out) })
+	return b.String()
+}
+
+// transliterate calls emit with the transliteration of every rune of s and its byte range.
+func (t *Transliterator) transliterate(s string, emit func(start, end int, out string)) {
+	var prev rune
+	inWord := false
+	for i, r := range s {
+		end := i + utf8.RuneLen(r)
+		emit(i, end, t.transliterateRune(prev, r, s[end:], inWord))
+		inWord = unicode.IsLetter(r) || unicode.Is(unicode.Mn, r) || inWord && isApostrophe(r)
+		prev = r
+	}
+}
+
+// transliterateRune transliterates r given the rune before it, the rest of the string and
+// whether r continues a word.
+func (t *Transliterator) transliterateRune(prev, r rune, rest string, inWord bool) string {
+	rep, ok := t.initial[r]
+	if !ok || inWord {
+		if rep, ok = t.runes[r]; !ok {
+			return unidecodeRune(r)
+		}
+	}
+	if unicode.IsUpper(r) && len(rep) > 1 {
+		next, _ := utf8.DecodeRuneInString(rest)
+		if unicode.IsUpper(prev) || unicode.IsUpper(next) {
+			return strings.ToUpper(rep)
+		}
+	}
+	return rep
+}
+
+// Transliterate appends a step transliterating with given transliterator.
+func (p *Pipeline) Transliterate(t *Transliterator) *Pipeline {
+	return p.Then(stepName("Transliterate", t.name), t.Transliterate)
+}
+
+// transliteratorFor returns the transliterator registered for given language tag or its base
+// language, if any.
+func transliteratorFor(lang string) *Transliterator {
+	if t, err := NewTransliterator(lang); err == nil {
+		return t
+	}
+	base, _ := language.Make(lang).Base()
+	if t, err := NewTransliterator(base.String()); err == nil {
+		return t
+	}
+	return nil
+}
+
+// unidecodeRune transliterates a single rune with the base unidecode table.
+func unidecodeRune(r rune) string {
+	if r < unicode.MaxASCII {
+		return string(r)
+	}
+	if tb, ok := table.Tables[r>>8]; ok && len(tb) > int(r%256) {
+		return tb[r%256]
+	}
+	return ""
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+func copyTransliterations(dst, src map[rune]string) {
+	for r, rep := range src {
+		dst[r] = rep
+	}
+}
+
+// deriveUpper adds upper case runes to given transliterations unless they are listed.
+func deriveUpper(m map[rune]string) map[rune]string {
+	out := make(map[rune]string, 2*len(m))
+	for r, rep := range m {
+		out[r] = rep
+		upper := unicode.ToUpper(r)
+		if _, ok := m[upper]; ok || upper == r {
+			continue
+		}
+		if rep == "" {
+			out[upper] = ""
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(rep)
+		out[upper] = string(unicode.ToUpper(first)) + rep[size:]
+	}
+	return out
+}
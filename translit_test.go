@@ -0,0 +1,65 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterate(t *testing.T) {
+	testCases := []struct {
+		input, profile, expected string
+	}{
+		{"Müller", "de", "Mueller"},
+		{"MÜLLER", "de", "MUELLER"},
+		{"Ölçer Straße", "de", "Oelcer Strasse"},
+		{"Ärger ÜBER Ä", "de", "Aerger UEBER Ae"},
+		{"Søren Ærø", "da", "Soeren Aeroe"},
+		{"Ålesund", "nb", "Aalesund"},
+		{"Ölçer Işık", "tr", "Olcer Isik"},
+		{"Əliyev", "az", "Eliyev"},
+		{"Щукин Жуков", "ru-iso9", "Ŝukin Žukov"},
+		{"Щукин Жуков", "ru-bgn", "Shchukin Zhukov"},
+		{"Ельцин", "ru-bgn", "Yelʼtsin"},
+		{"Ельцин", "ru", "Yelʼtsin"},
+		{"Юрій Їжакевич", "uk-national", "Yurii Yizhakevych"},
+		{"Знам'янка", "uk", "Znamianka"},
+		{"ЯЛТА", "uk-national", "YALTA"},
+	}
+
+	for _, testCase := range testCases {
+		res, err := Transliterate(testCase.input, testCase.profile)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, res, "for %q with %q", testCase.input, testCase.profile)
+	}
+
+	_, err := Transliterate("Müller", "xx")
+	require.Error(t, err)
+}
+
+func TestRegisterTransliteration(t *testing.T) {
+	require.NoError(t, RegisterTransliteration("de-test", TransliterationProfile{
+		Base:  "de",
+		Runes: map[rune]string{'ß': "sz"},
+	}))
+	res, err := Transliterate("Weiß Müller", "de-test")
+	require.NoError(t, err)
+	require.Equal(t, "Weisz Mueller", res)
+	require.Contains(t, TransliterationProfiles(), "de-test")
+
+	require.Error(t, RegisterTransliteration("broken", TransliterationProfile{Base: "xx"}))
+	require.NotContains(t, TransliterationProfiles(), "broken")
+
+	tr, err := NewTransliterator("de")
+	require.NoError(t, err)
+	p := NewPipeline().Transliterate(tr).ToLower()
+	require.Equal(t, "mueller", p.Apply("Müller"))
+	require.Equal(t, `Transliterate("de") -> ToLower`, p.String())
+}
+
+func TestNormalizeLocaleTransliteration(t *testing.T) {
+	require.Equal(t, "mueller", NormalizeLocale("Müller", "de"))
+	require.Equal(t, "mueller", NormalizeLocale("MÜLLER", "de-CH"))
+	require.Equal(t, "muller", NormalizeLocale("Müller", "fr"))
+	require.Equal(t, "soeren", NormalizeLocale("Søren", "da"))
+}
@@ -0,0 +1,199 @@
+package agstring
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// PrefixTrimmer removes leading prefixes from strings, such as titles from names. It is built
+// once from a prefix list and is safe for concurrent use.
+//
+// Prefixes are only removed at word boundaries, so prefix "dr" is removed from "dr smith" but
+// not from "drake". Removal is repeated until no prefix is left, trying longer
+// prefixes first, and space is trimmed from both ends of the result.
+type PrefixTrimmer struct {
+	// kind and args describe the trimmer, see String.
+	kind     string
+	args     []string
+	literals *PrefixSet
+	res      []*regexp.Regexp
+}
+
+// NewPrefixTrimmer returns a trimmer of given literal prefixes, e.g. "Dr." or "C++". Empty
+// prefixes are ignored.
+func NewPrefixTrimmer(prefixes ...string) *PrefixTrimmer {
+	return &PrefixTrimmer{
+		kind:     "PrefixTrimmer",
+		args:     append([]string(nil), prefixes...),
+		literals: NewPrefixSet(NonEmpty(prefixes)...),
+	}
+}
+
+// NewRegexpPrefixTrimmer returns a trimmer of prefixes given as regular expressions, such as
+// `(?i)mrs?\.?`. An error is returned if a pattern doesn't compile.
+func NewRegexpPrefixTrimmer(patterns ...string) (*PrefixTrimmer, error) {
+	res, err := compileTrimPatterns(patterns, "^(?:", ")")
+	if err != nil {
+		return nil, err
+	}
+	return &PrefixTrimmer{
+		kind:     "RegexpPrefixTrimmer",
+		args:     append([]string(nil), patterns...),
+		literals: NewPrefixSet(),
+		res:      res,
+	}, nil
+}
+
+func (t *PrefixTrimmer) String() string { return stepName(t.kind, t.args...) }
+
+// Trim removes the prefixes and space from given string.
+func (t *PrefixTrimmer) Trim(s string) string {
+	s = strings.TrimSpace(s)
+	for {
+		cut := t.longestPrefix(s)
+		if cut <= 0 {
+			return s
+		}
+		s = strings.TrimSpace(s[cut:])
+	}
+}
+
+// longestPrefix returns the length of the longest prefix of s ending at a word boundary.
+func (t *PrefixTrimmer) longestPrefix(s string) int {
+	cut := 0
+	literals := t.literals.AllPrefixes(s)
+	for i := len(literals) - 1; i >= 0; i-- {
+		if isWordBoundary(s, len(literals[i])) {
+			cut = len(literals[i])
+			break
+		}
+	}
+	for _, re := range t.res {
+		if loc := re.FindStringIndex(s); loc != nil && loc[1] > cut && isWordBoundary(s, loc[1]) {
+			cut = loc[1]
+		}
+	}
+	return cut
+}
+
+// SuffixTrimmer removes trailing suffixes from strings, such as legal forms from company
+// names. It generalizes TrimSuffixes: suffixes are only removed at word boundaries, so suffix
+// "co" is removed from "acme co" but not from "tabasco", and removal is repeated until no
+// suffix is left, trying longer suffixes first. Space is trimmed from both ends of the result.
+// It is safe for concurrent use.
+type SuffixTrimmer struct {
+	// kind and args describe the trimmer, see String.
+	kind string
+	args []string
+	// reversed holds the literal suffixes with their bytes reversed.
+	reversed *PrefixSet
+	res      []*regexp.Regexp
+}
+
+// NewSuffixTrimmer returns a trimmer of given literal suffixes, e.g. "Ltd." or "& Co". Empty
+// suffixes are ignored.
+func NewSuffixTrimmer(suffixes ...string) *SuffixTrimmer {
+	reversed := make([]string, 0, len(suffixes))
+	for _, suffix := range NonEmpty(suffixes) {
+		reversed = append(reversed, reverseBytes(suffix))
+	}
+	return &SuffixTrimmer{
+		kind:     "SuffixTrimmer",
+		args:     append([]string(nil), suffixes...),
+		reversed: NewPrefixSet(reversed...),
+	}
+}
+
+// NewRegexpSuffixTrimmer returns a trimmer of suffixes given as regular expressions, such as
+// `(?i)l\.?t\.?d\.?`. An error is returned if a pattern doesn't compile.
+func NewRegexpSuffixTrimmer(patterns ...string) (*SuffixTrimmer, error) {
+	res, err := compileTrimPatterns(patterns, "(?:", ")$")
+	if err != nil {
+		return nil, err
+	}
+	return &SuffixTrimmer{
+		kind:     "RegexpSuffixTrimmer",
+		args:     append([]string(nil), patterns...),
+		reversed: NewPrefixSet(),
+		res:      res,
+	}, nil
+}
+
+func (t *SuffixTrimmer) String() string { return stepName(t.kind, t.args...) }
+
+// Trim removes the suffixes and space from given string.
+func (t *SuffixTrimmer) Trim(s string) string {
+	s = strings.TrimSpace(s)
+	for {
+		start := t.longestSuffix(s)
+		if start >= len(s) {
+			return s
+		}
+		s = strings.TrimSpace(s[:start])
+	}
+}
+
+// longestSuffix returns the start of the longest suffix of s starting at a word boundary, or
+// len(s) when there is none.
+func (t *SuffixTrimmer) longestSuffix(s string) int {
+	start := len(s)
+	literals := t.reversed.AllPrefixes(reverseBytes(s))
+	for i := len(literals) - 1; i >= 0; i-- {
+		if isWordBoundary(s, len(s)-len(literals[i])) {
+			start = len(s) - len(literals[i])
+			break
+		}
+	}
+	for _, re := range t.res {
+		if loc := re.FindStringIndex(s); loc != nil && loc[0] < start && isWordBoundary(s, loc[0]) {
+			start = loc[0]
+		}
+	}
+	return start
+}
+
+// compileTrimPatterns compiles given patterns wrapped with given prefix and suffix.
+func compileTrimPatterns(patterns []string, prefix, suffix string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range NonEmpty(patterns) {
+		re, err := regexp.Compile(prefix + p + suffix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trim pattern %q", p)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// isWordBoundary checks if cutting s at byte i doesn't split a word, i.e. i is at either end
+// of s or the runes around it are not both word characters.
+func isWordBoundary(s string, i int) bool {
+	if i <= 0 || i >= len(s) {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	return !isWordRune(before) || !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r)
+}
+
+func reverseBytes(s string) string {
+	b := make([]byte, len(s))
+	for i := range b {
+		b[i] = s[len(s)-1-i]
+	}
+	return string(b)
+}
+
+// TrimPrefixesWith appends a step trimming strings with given prefix trimmer.
+func (p *Pipeline) TrimPrefixesWith(t *PrefixTrimmer) *Pipeline { return p.Then(t.String(), t.Trim) }
+
+// TrimSuffixesWith appends a step trimming strings with given suffix trimmer.
+func (p *Pipeline) TrimSuffixesWith(t *SuffixTrimmer) *Pipeline { return p.Then(t.String(), t.Trim) }
@@ -0,0 +1,84 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixTrimmer(t *testing.T) {
+	testCases := []struct {
+		input    string
+		prefixes []string
+		expected string
+	}{
+		{"Dr. Smith", []string{"Dr", "Dr."}, "Smith"},
+		{"Dr.Smith", []string{"Dr."}, "Smith"},
+		{"Drake", []string{"Dr"}, "Drake"},
+		{"C++ developer", []string{"C++"}, "developer"},
+		{"C++developer", []string{"C++"}, "developer"},
+		{" Prof. Dr. Smith ", []string{"Dr.", "Prof."}, "Smith"},
+		{"mr mrs smith", []string{"mr", "mrs"}, "smith"},
+		{"a.b smith", []string{"a.b", "a+b"}, "smith"},
+		{"a+b smith", []string{"a.b"}, "a+b smith"},
+		{"Dr. Smith", []string{""}, "Dr. Smith"},
+		{"Dr.", []string{"Dr."}, ""},
+		{"", []string{"Dr."}, ""},
+	}
+
+	for _, testCase := range testCases {
+		trimmer := NewPrefixTrimmer(testCase.prefixes...)
+		require.Equal(t, testCase.expected, trimmer.Trim(testCase.input), "for %q", testCase.input)
+	}
+}
+
+func TestRegexpPrefixTrimmer(t *testing.T) {
+	trimmer, err := NewRegexpPrefixTrimmer(`(?i)mrs?\.?`, `(?i)dr\.?`)
+	require.NoError(t, err)
+	require.Equal(t, "Smith", trimmer.Trim("Mrs. Dr Smith"))
+	require.Equal(t, "Mrsmith", trimmer.Trim("Mrsmith"))
+	require.Equal(t, `RegexpPrefixTrimmer("(?i)mrs?\\.?", "(?i)dr\\.?")`, trimmer.String())
+
+	_, err = NewRegexpPrefixTrimmer("dr", "(mr")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `invalid trim pattern "(mr"`)
+}
+
+func TestSuffixTrimmer(t *testing.T) {
+	testCases := []struct {
+		input    string
+		suffixes []string
+		expected string
+	}{
+		{"Acme Co Ltd", []string{"co", "Co", "Ltd"}, "Acme"},
+		{"Acme Co. Ltd.", []string{"Co.", "Ltd."}, "Acme"},
+		{"tabasco", []string{"co"}, "tabasco"},
+		{"Acme & Co", []string{"Co", "& Co"}, "Acme"},
+		{"Acme GmbH & Co. KG", []string{"& Co. KG", "KG", "GmbH"}, "Acme"},
+		{"Ltd", []string{"Ltd"}, ""},
+		{"", []string{"Ltd"}, ""},
+	}
+
+	for _, testCase := range testCases {
+		trimmer := NewSuffixTrimmer(testCase.suffixes...)
+		require.Equal(t, testCase.expected, trimmer.Trim(testCase.input), "for %q", testCase.input)
+	}
+}
+
+func TestRegexpSuffixTrimmer(t *testing.T) {
+	trimmer, err := NewRegexpSuffixTrimmer(`(?i)l\.?t\.?d\.?`, `(?i)inc\.?`)
+	require.NoError(t, err)
+	require.Equal(t, "Acme", trimmer.Trim("Acme Inc. L.T.D."))
+	require.Equal(t, "Acmeltd", trimmer.Trim("Acmeltd"))
+
+	_, err = NewRegexpSuffixTrimmer("[a-")
+	require.Error(t, err)
+}
+
+func TestTrimmerPipeline(t *testing.T) {
+	p := NewPipeline().
+		TrimPrefixesWith(NewPrefixTrimmer("Dr.")).
+		TrimSuffixesWith(NewSuffixTrimmer("Jr."))
+	require.Equal(t, "John Smith", p.Apply(" Dr. John Smith Jr. "))
+	require.Equal(t, `PrefixTrimmer("Dr.") -> SuffixTrimmer("Jr.")`, p.String())
+}
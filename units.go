@@ -0,0 +1,304 @@
+package agstring
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Unit is a unit of measure.
+type Unit struct {
+	// Name is the canonical symbol of the unit, e.g. "m²".
+	Name string
+	// Dimension groups the units quantities convert between, e.g. "area".
+	Dimension string
+	// Factor is the size of the unit in the base unit of its dimension, e.g. 1000 for "kg" in
+	// "mass" based on the gram. Units of a dimension must agree on the base unit.
+	Factor float64
+	// Offset is added after scaling to the base unit, for temperatures.
+	Offset float64
+}
+
+// Quantity is an amount of a unit found in a string.
+type Quantity struct {
+	Value float64
+	// Unit is the canonical name of the unit, e.g. "m²" for "sq m".
+	Unit string
+	// Start and End are the byte offsets of the quantity in the searched string.
+	Start, End int
+}
+
+var (
+	unitsMu sync.RWMutex
+	units   = make(map[string]Unit)
+	// unitAliases maps aliases to unit names. ASCII aliases longer than a letter also match in
+	// any case, through their lower case form in foldedUnitAliases.
+	unitAliases, foldedUnitAliases   = make(map[string]string), make(map[string]string)
+	unitAliasSet, foldedUnitAliasSet = NewPrefixSet(), NewPrefixSet()
+)
+
+var (
+	// caseSensitiveUnitAliases holds the short aliases which match in their exact case only, as
+	// they are also words or abbreviations in other cases, e.g. "PT" or "In".
+	caseSensitiveUnitAliases = map[string]bool{
+		"in": true, "mi": true, "pt": true, "ac": true, "ba": true, "bd": true, "br": true,
+	}
+	// wordUnitAliases holds the aliases which are also words, and don't match when a lower case
+	// word follows them, as "in" in "3 in the lobby".
+	wordUnitAliases = map[string]bool{"in": true, "ba": true}
+	// unmatchedUnitAliases holds the names Convert accepts but ExtractQuantities doesn't match,
+	// as "K" in "50K" mostly means thousands rather than kelvins.
+	unmatchedUnitAliases = map[string]bool{"K": true}
+)
+
+var builtinUnits = []struct {
+	unit    Unit
+	aliases []string
+}{
+	{Unit{"mm", "length", 0.001, 0}, []string{"millimeter", "millimeters", "millimetre", "millimetres"}},
+	{Unit{"cm", "length", 0.01, 0}, []string{"centimeter", "centimeters", "centimetre", "centimetres"}},
+	{Unit{"m", "length", 1, 0}, []string{"meter", "meters", "metre", "metres"}},
+	{Unit{"km", "length", 1000, 0}, []string{"kms", "kilometer", "kilometers", "kilometre", "kilometres"}},
+	{Unit{"in", "length", 0.0254, 0}, []string{`"`, "″", "”", "inch", "inches"}},
+	{Unit{"ft", "length", 0.3048, 0}, []string{"'", "′", "’", "foot", "feet"}},
+	{Unit{"yd", "length", 0.9144, 0}, []string{"yard", "yards"}},
+	{Unit{"mi", "length", 1609.344, 0}, []string{"mile", "miles"}},
+
+	{Unit{"cm²", "area", 0.0001, 0}, []string{"cm2", "sq cm", "square centimeter", "square centimeters"}},
+	{Unit{"m²", "area", 1, 0}, []string{
+		"m2", "sqm", "sq m", "sq. m", "square meter", "square meters", "square metre", "square metres",
+	}},
+	{Unit{"km²", "area", 1e6, 0}, []string{"km2", "sq km", "square kilometer", "square kilometers"}},
+	{Unit{"ft²", "area", 0.09290304, 0}, []string{
+		"ft2", "sqft", "sq ft", "sq. ft.", "sq. ft", "square foot", "square feet",
+	}},
+	{Unit{"ac", "area", 4046.8564224, 0}, []string{"acre", "acres"}},
+	{Unit{"ha", "area", 10000, 0}, []string{"hectare", "hectares"}},
+
+	{Unit{"ml", "volume", 0.001, 0}, []string{"mL", "milliliter", "milliliters", "millilitre", "millilitres"}},
+	{Unit{"cl", "volume", 0.01, 0}, []string{"cL", "centiliter", "centiliters", "centilitre", "centilitres"}},
+	{Unit{"l", "volume", 1, 0}, []string{"L", "ltr", "liter", "liters", "litre", "litres"}},
+	{Unit{"m³", "volume", 1000, 0}, []string{"m3", "cubic meter", "cubic meters", "cubic metre", "cubic metres"}},
+	{Unit{"fl oz", "volume", 0.0295735295625, 0}, []string{"fl. oz.", "fl. oz", "fluid ounce", "fluid ounces"}},
+	{Unit{"pt", "volume", 0.473176473, 0}, []string{"pint", "pints"}},
+	{Unit{"qt", "volume", 0.946352946, 0}, []string{"quart", "quarts"}},
+	{Unit{"gal", "volume", 3.785411784, 0}, []string{"gallon", "gallons"}},
+
+	{Unit{"mg", "mass", 0.001, 0}, []string{"milligram", "milligrams"}},
+	{Unit{"g", "mass", 1, 0}, []string{"gr", "gram", "grams", "gramme", "grammes"}},
+	{Unit{"kg", "mass", 1000, 0}, []string{"kgs", "kilo", "kilos", "kilogram", "kilograms"}},
+	{Unit{"t", "mass", 1e6, 0}, []string{"tonne", "tonnes", "metric ton", "metric tons"}},
+	{Unit{"oz", "mass", 28.349523125, 0}, []string{"ounce", "ounces"}},
+	{Unit{"lb", "mass", 453.59237, 0}, []string{"lbs", "pound", "pounds"}},
+
+	{Unit{"°C", "temperature", 1, 273.15}, []string{"ºC", "℃", "celsius", "degrees celsius"}},
+	{Unit{"°F", "temperature", 5.0 / 9, 459.67 * 5 / 9}, []string{"ºF", "℉", "fahrenheit", "degrees fahrenheit"}},
+	{Unit{"K", "temperature", 1, 0}, []string{"°K", "kelvin", "kelvins"}},
+
+	{Unit{"m/s", "speed", 1, 0}, []string{"meters per second", "metres per second"}},
+	{Unit{"km/h", "speed", 1 / 3.6, 0}, []string{"kmh", "kph", "kilometers per hour", "kilometres per hour"}},
+	{Unit{"mph", "speed", 0.44704, 0}, []string{"miles per hour"}},
+
+	{Unit{"bedroom", "bedrooms", 1, 0}, []string{"bed", "beds", "bd", "bdr", "bdrm", "bdrms", "br", "bedrooms"}},
+	{Unit{"bathroom", "bathrooms", 1, 0}, []string{"bath", "baths", "ba", "bathrooms"}},
+}
+
+func init() {
+	for _, u := range builtinUnits {
+		if err := RegisterUnit(u.unit, u.aliases...); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterUnit registers given unit under its name and given aliases, replacing any unit or
+// alias registered with the same name. ASCII names and aliases longer than a letter match in
+// any case, so "KG" is "kg" but "K" isn't "k", except built-in ones which are also words, such
+// as "in" or "pt".
+func RegisterUnit(u Unit, aliases ...string) error {
+	if u.Name == "" || u.Dimension == "" || u.Factor == 0 {
+		return errors.Errorf("invalid unit %+v", u)
+	}
+	unitsMu.Lock()
+	defer unitsMu.Unlock()
+	units[u.Name] = u
+	addUnitAliases(u.Name, append([]string{u.Name}, aliases...))
+	return nil
+}
+
+// RegisterUnitAlias registers an alias of the registered unit of given name, e.g. "m²" for
+// "sq m".
+func RegisterUnitAlias(alias, name string) error {
+	unitsMu.Lock()
+	defer unitsMu.Unlock()
+	if _, ok := units[name]; !ok {
+		return errors.Errorf("unknown unit %q", name)
+	}
+	if alias == "" {
+		return errors.Errorf("invalid alias of unit %q", name)
+	}
+	addUnitAliases(name, []string{alias})
+	return nil
+}
+
+// addUnitAliases adds the aliases of given unit and rebuilds the alias sets, which must be
+// locked for writing.
+func addUnitAliases(name string, aliases []string) {
+	for _, alias := range aliases {
+		unitAliases[alias] = name
+		if isASCII(alias) && len(alias) > 1 && !caseSensitiveUnitAliases[alias] {
+			foldedUnitAliases[strings.ToLower(alias)] = name
+		}
+	}
+	exact := make([]string, 0, len(unitAliases))
+	for alias := range unitAliases {
+		if !unmatchedUnitAliases[alias] {
+			exact = append(exact, alias)
+		}
+	}
+	folded := make([]string, 0, len(foldedUnitAliases))
+	for alias := range foldedUnitAliases {
+		folded = append(folded, alias)
+	}
+	unitAliasSet, foldedUnitAliasSet = NewPrefixSet(exact...), NewPrefixSet(folded...)
+}
+
+// unitFor returns the unit of given name or alias.
+func unitFor(alias string) (Unit, bool) {
+	unitsMu.RLock()
+	defer unitsMu.RUnlock()
+	name, ok := unitAliases[alias]
+	if !ok {
+		name, ok = foldedUnitAliases[strings.ToLower(alias)]
+	}
+	return units[name], ok
+}
+
+var (
+	quantityNumberRegexp = regexp.MustCompile(`[-−]?\p{Nd}+(?:[.,]\p{Nd}+)*`)
+	feetInchesRegexp     = regexp.MustCompile(`(\p{Nd}+)\s*(?:'|’|′|ft\.?|feet|foot)\s*` +
+		`(\p{Nd}+(?:[.,]\p{Nd}+)?)\s*(?:"|”|″|''|in\b\.?|inch(?:es)?\b)?`)
+)
+
+// ExtractQuantities returns the quantities found in given string, such as "120 m²", "1.5kg",
+// "2,5 l", "3 bed" or heights in feet and inches, "5'11\"", which are returned in inches.
+// Units are recognized by their registered names and aliases, see RegisterUnit, and decimal
+// separators are guessed like SafeParseDecimal does.
+func ExtractQuantities(s string) []Quantity {
+	var found []Quantity
+	for _, m := range feetInchesRegexp.FindAllStringSubmatchIndex(s, -1) {
+		feet, err1 := SafeParseFloat(s[m[2]:m[3]], "")
+		inches, err2 := SafeParseFloat(s[m[4]:m[5]], "")
+		if err1 == nil && err2 == nil && inches < 12 && isQuantityBoundary(s, m[0], m[1]) {
+			found = append(found, Quantity{Value: feet*12 + inches, Unit: "in", Start: m[0], End: m[1]})
+		}
+	}
+
+	var quantities []Quantity
+	next := 0
+	for _, m := range quantityNumberRegexp.FindAllStringIndex(s, -1) {
+		for next < len(found) && found[next].End <= m[0] {
+			quantities = append(quantities, found[next])
+			next++
+		}
+		if next < len(found) && found[next].Start < m[1] || !isQuantityBoundary(s, m[0], len(s)) {
+			continue
+		}
+		if q, ok := parseQuantityAt(s, m[0], m[1]); ok {
+			quantities = append(quantities, q)
+		}
+	}
+	return append(quantities, found[next:]...)
+}
+
+// parseQuantityAt returns the quantity of the number from start to end of s and the unit
+// following it.
+func parseQuantityAt(s string, start, end int) (Quantity, bool) {
+	value, err := SafeParseFloat(s[start:end], "")
+	if err != nil {
+		return Quantity{}, false
+	}
+	rest := s[end:]
+	if r, size := utf8.DecodeRuneInString(rest); r == ' ' || r == '\u00a0' || r == '\u202f' {
+		rest = rest[size:]
+	}
+	offset := len(s) - len(rest)
+	unitsMu.RLock()
+	candidates := unitAliasSet.AllPrefixes(rest)
+	for _, alias := range foldedUnitAliasSet.AllPrefixes(asciiLower(rest, 32)) {
+		candidates = append(candidates, rest[:len(alias)])
+	}
+	unitsMu.RUnlock()
+
+	longest := ""
+	for _, alias := range candidates {
+		if len(alias) > len(longest) && isQuantityBoundary(s, start, offset+len(alias)) &&
+			!(wordUnitAliases[alias] && startsWithLowerWord(s[offset+len(alias):])) {
+			longest = alias
+		}
+	}
+	u, ok := unitFor(longest)
+	if longest == "" || !ok {
+		return Quantity{}, false
+	}
+	return Quantity{Value: value, Unit: u.Name, Start: start, End: offset + len(longest)}, true
+}
+
+// startsWithLowerWord checks if s starts with spaces followed by a lower case word.
+func startsWithLowerWord(s string) bool {
+	word := strings.TrimLeftFunc(s, unicode.IsSpace)
+	r, _ := utf8.DecodeRuneInString(word)
+	return len(word) < len(s) && unicode.IsLower(r)
+}
+
+// asciiLower lowers the ASCII letters of the first n bytes of s, keeping byte offsets.
+func asciiLower(s string, n int) string {
+	b := []byte(s[:minInt(n, len(s))])
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// isQuantityBoundary checks if the match of s from start to end isn't part of a longer word.
+func isQuantityBoundary(s string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	after, _ := utf8.DecodeRuneInString(s[end:])
+	return !(start > 0 && (unicode.IsLetter(before) || unicode.IsDigit(before) || before == '.' || before == ',')) &&
+		!(end < len(s) && (unicode.IsLetter(after) || unicode.IsDigit(after)))
+}
+
+// ParseQuantity returns the quantity given string consists of. See ExtractQuantities.
+func ParseQuantity(s string) (Quantity, error) {
+	trimmed := strings.TrimSpace(s)
+	found := ExtractQuantities(trimmed)
+	if len(found) != 1 || found[0].Start != 0 || found[0].End != len(trimmed) {
+		return Quantity{}, errors.Errorf("invalid quantity %q", s)
+	}
+	return found[0], nil
+}
+
+// Convert returns given quantity in given unit, which may be an alias, e.g. 1.5 "kg" is 3.31
+// "lbs". An error is returned for unknown units and units of different dimensions.
+func Convert(q Quantity, unit string) (Quantity, error) {
+	from, ok := unitFor(q.Unit)
+	if !ok {
+		return q, errors.Errorf("unknown unit %q", q.Unit)
+	}
+	to, ok := unitFor(unit)
+	if !ok {
+		return q, errors.Errorf("unknown unit %q", unit)
+	}
+	if from.Dimension != to.Dimension {
+		return q, errors.Errorf("can't convert %s to %s", from.Name, to.Name)
+	}
+	q.Value = (q.Value*from.Factor + from.Offset - to.Offset) / to.Factor
+	q.Unit = to.Name
+	return q, nil
+}
@@ -0,0 +1,105 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractQuantities(t *testing.T) {
+	testCases := []struct {
+		input string
+		value float64
+		unit  string
+		text  string
+	}{
+		{"flat of 120 m² in town", 120, "m²", "120 m²"},
+		{"1.5kg", 1.5, "kg", "1.5kg"},
+		{"2,5 l", 2.5, "l", "2,5 l"},
+		{"20 KG bag", 20, "kg", "20 KG"},
+		{"he is 5'11\" tall", 71, "in", "5'11\""},
+		{"5 ft 11 in", 71, "in", "5 ft 11 in"},
+		{"3 bed house", 3, "bedroom", "3 bed"},
+		{"1,500 sq ft apt", 1500, "ft²", "1,500 sq ft"},
+		{"12 sq. ft. room", 12, "ft²", "12 sq. ft."},
+		{"-5 °C", -5, "°C", "-5 °C"},
+		{"60 mph", 60, "mph", "60 mph"},
+		{"12 in. long", 12, "in", "12 in"},
+		{"2 ba, garage", 2, "bathroom", "2 ba"},
+		{"a 2 pt jug", 2, "pt", "2 pt"},
+		{"300 °K", 300, "K", "300 °K"},
+		{"300 kelvins", 300, "K", "300 kelvins"},
+	}
+
+	for _, testCase := range testCases {
+		found := ExtractQuantities(testCase.input)
+		require.Len(t, found, 1, "for %q", testCase.input)
+		require.Equal(t, testCase.value, found[0].Value, "for %q", testCase.input)
+		require.Equal(t, testCase.unit, found[0].Unit, "for %q", testCase.input)
+		require.Equal(t, testCase.text, testCase.input[found[0].Start:found[0].End], "for %q", testCase.input)
+	}
+
+	require.Equal(t, []Quantity{
+		{Value: 3, Unit: "bedroom", Start: 0, End: 5},
+		{Value: 2, Unit: "bathroom", Start: 6, End: 13},
+	}, ExtractQuantities("3 bed 2 baths"))
+	for _, input := range []string{
+		"5 mice", "A4 paper", "3-4 bed", "12k followers", "12", "salary 50K", "costs $50K",
+		"meet at 3 in the lobby", "took 5 ba courses", "Top 10 PT", "5 BR", "2 In",
+	} {
+		require.Empty(t, ExtractQuantities(input), "for %q", input)
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	q, err := ParseQuantity(" 1.5 kilograms ")
+	require.NoError(t, err)
+	require.Equal(t, Quantity{Value: 1.5, Unit: "kg", Start: 0, End: 13}, q)
+
+	for _, input := range []string{"", "1.5", "kg", "1.5 kg and 2 kg"} {
+		_, err = ParseQuantity(input)
+		require.Error(t, err, "for %q", input)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{1.5, "kg", "lbs", 3.3069},
+		{120, "m²", "sq ft", 1291.6693},
+		{71, "in", "cm", 180.34},
+		{2.5, "l", "ml", 2500},
+		{100, "°C", "°F", 212},
+		{98.6, "°F", "°C", 37},
+		{0, "°C", "K", 273.15},
+	}
+
+	for _, testCase := range testCases {
+		q, err := Convert(Quantity{Value: testCase.value, Unit: testCase.from}, testCase.to)
+		require.NoError(t, err)
+		require.InDelta(t, testCase.expected, q.Value, 0.0001, "for %v %s", testCase.value, testCase.from)
+	}
+
+	_, err := Convert(Quantity{Value: 1, Unit: "kg"}, "m")
+	require.Error(t, err)
+	_, err = Convert(Quantity{Value: 1, Unit: "kg"}, "parsec")
+	require.Error(t, err)
+}
+
+func TestRegisterUnit(t *testing.T) {
+	require.NoError(t, RegisterUnit(Unit{Name: "st", Dimension: "mass", Factor: 6350.29318}, "stone", "stones"))
+	require.NoError(t, RegisterUnitAlias("Pfund", "lb"))
+	require.Error(t, RegisterUnitAlias("furlong", "fur"))
+	require.Error(t, RegisterUnit(Unit{Name: "x"}))
+
+	found := ExtractQuantities("11 stone or 2 Pfund")
+	require.Len(t, found, 2)
+	require.Equal(t, "st", found[0].Unit)
+	require.Equal(t, "lb", found[1].Unit)
+	q, err := Convert(found[0], "kg")
+	require.NoError(t, err)
+	require.InDelta(t, 69.853, q.Value, 0.001)
+}
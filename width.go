@@ -0,0 +1,116 @@
+package agstring
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/width"
+)
+
+// WidthOptions configures the measurement of display widths, i.e. the number of cells a
+// string occupies in a terminal or a fixed-width text report. Widths follow the Unicode
+// East_Asian_Width property: wide and full-width characters, such as CJK ideographs, take two
+// cells, combining marks and control characters take none and emoji sequences take two.
+type WidthOptions struct {
+	// AmbiguousWide measures characters of ambiguous East Asian width, such as "°", "±",
+	// "①" or Greek and Cyrillic letters, as two cells, as CJK fonts commonly render them.
+	// By default they take one cell.
+	AmbiguousWide bool
+}
+
+// Width returns the display width of given string.
+func (o WidthOptions) Width(s string) int {
+	w, state := 0, -1
+	for len(s) > 0 {
+		var g string
+		var gw int
+		g, s, gw, state = uniseg.FirstGraphemeClusterInString(s, state)
+		w += o.graphemeWidth(g, gw)
+	}
+	return w
+}
+
+// graphemeWidth adjusts the width w measured for grapheme g to the options.
+func (o WidthOptions) graphemeWidth(g string, w int) int {
+	if o.AmbiguousWide && w == 1 {
+		r, _ := utf8.DecodeRuneInString(g)
+		if width.LookupRune(r).Kind() == width.EastAsianAmbiguous {
+			return 2
+		}
+	}
+	return w
+}
+
+// PadLeft pads given string on the left up to given display width. The padding is made of
+// spaces unless another pad string is given.
+func (o WidthOptions) PadLeft(s string, w int, pad ...string) string {
+	return o.padding(w-o.Width(s), pad) + s
+}
+
+// PadRight pads given string on the right up to given display width. The padding is made of
+// spaces unless another pad string is given.
+func (o WidthOptions) PadRight(s string, w int, pad ...string) string {
+	return s + o.padding(w-o.Width(s), pad)
+}
+
+// Center pads given string on both sides up to given display width. When the padding can't be
+// split evenly, the right side gets the larger part.
+func (o WidthOptions) Center(s string, w int, pad ...string) string {
+	n := w - o.Width(s)
+	if n <= 0 {
+		return s
+	}
+	return o.padding(n/2, pad) + s + o.padding(n-n/2, pad)
+}
+
+// FitWidth truncates given string to given display width, marking cut strings with
+// DefaultEllipsis, and pads it on the right, so that the result takes exactly given width.
+func (o WidthOptions) FitWidth(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	s = truncate(s, TruncateOptions{MaxWidth: w, Ellipsis: DefaultEllipsis, BreakWords: true}, o)
+	return o.PadRight(s, w)
+}
+
+// padding returns a padding of n cells made of given pad, filling with the beginning of the
+// pad and spaces the cells the whole pad doesn't fit into.
+func (o WidthOptions) padding(n int, pad []string) string {
+	if n <= 0 {
+		return ""
+	}
+	p := " "
+	if len(pad) > 0 && pad[0] != "" {
+		p = pad[0]
+	}
+	pw := o.Width(p)
+	if pw <= 0 {
+		return strings.Repeat(" ", n)
+	}
+	var rest string
+	if n%pw > 0 {
+		rest = truncate(p, TruncateOptions{MaxWidth: n % pw, BreakWords: true}, o)
+	}
+	return strings.Repeat(p, n/pw) + rest + strings.Repeat(" ", n%pw-o.Width(rest))
+}
+
+// Width returns the display width of given string, measuring ambiguous width characters as
+// one cell. See WidthOptions.
+func Width(s string) int { return WidthOptions{}.Width(s) }
+
+// PadLeft pads given string on the left up to given display width. See WidthOptions.PadLeft.
+func PadLeft(s string, w int, pad ...string) string { return WidthOptions{}.PadLeft(s, w, pad...) }
+
+// PadRight pads given string on the right up to given display width. See
+// WidthOptions.PadRight.
+func PadRight(s string, w int, pad ...string) string {
+	return WidthOptions{}.PadRight(s, w, pad...)
+}
+
+// Center pads given string on both sides up to given display width. See WidthOptions.Center.
+func Center(s string, w int, pad ...string) string { return WidthOptions{}.Center(s, w, pad...) }
+
+// FitWidth truncates and pads given string to take exactly given display width. See
+// WidthOptions.FitWidth.
+func FitWidth(s string, w int) string { return WidthOptions{}.FitWidth(s, w) }
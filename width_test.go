@@ -0,0 +1,72 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWidth(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expected, wide int
+	}{
+		{"", 0, 0},
+		{"hello", 5, 5},
+		{"ドンキーコング", 14, 14},
+		{"東京 Tokyo", 10, 10},
+		{"ＡＢＣ", 6, 6},
+		{"ｶﾀｶﾅ", 4, 4},
+		{"été", 3, 3},
+		{"👩‍💻", 2, 2},
+		{"🇹🇷", 2, 2},
+		{"±°", 2, 4},
+		{"Ωмега", 5, 10},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, Width(testCase.input), "for %q", testCase.input)
+		require.Equal(t, testCase.wide, WidthOptions{AmbiguousWide: true}.Width(testCase.input),
+			"for %q with ambiguous wide", testCase.input)
+	}
+}
+
+func TestPad(t *testing.T) {
+	require.Equal(t, "   abc", PadLeft("abc", 6))
+	require.Equal(t, "abc   ", PadRight("abc", 6))
+	require.Equal(t, "abcdef", PadRight("abcdef", 3))
+	require.Equal(t, "  東京", PadLeft("東京", 6))
+	require.Equal(t, "東京  ", PadRight("東京", 6))
+	require.Equal(t, "..abc", PadLeft("abc", 5, "."))
+	require.Equal(t, "　　 abc", PadLeft("abc", 8, "　"))
+	require.Equal(t, " ± ", WidthOptions{AmbiguousWide: true}.Center("±", 4))
+	require.Equal(t, " ± ", Center("±", 3))
+	require.Equal(t, " 東京  ", Center("東京", 7))
+	require.Equal(t, "-=-abc-=-", Center("abc", 9, "-="))
+	require.Equal(t, "abc", Center("abc", 2))
+}
+
+func TestFitWidth(t *testing.T) {
+	testCases := []struct {
+		input    string
+		width    int
+		expected string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcdef", 5, "abcd…"},
+		{"ドンキーコング", 6, "ドン… "},
+		{"ドンキーコング", 7, "ドンキ…"},
+		{"abc", 0, ""},
+	}
+
+	for _, testCase := range testCases {
+		res := FitWidth(testCase.input, testCase.width)
+		require.Equal(t, testCase.expected, res, "for %q", testCase.input)
+		require.Equal(t, testCase.width, Width(res))
+	}
+}
+
+func TestTruncateAmbiguousWide(t *testing.T) {
+	require.Equal(t, "±±±", Truncate("±±±", TruncateOptions{MaxWidth: 3}))
+	require.Equal(t, "±", Truncate("±±±", TruncateOptions{MaxWidth: 3, AmbiguousWide: true}))
+}
@@ -0,0 +1,193 @@
+package agstring
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// WordReplacerOptions configures a WordReplacer.
+type WordReplacerOptions struct {
+	// CaseInsensitive matches words regardless of letter case.
+	CaseInsensitive bool
+	// PreserveCase matches words regardless of letter case and adapts the case of replacements
+	// to the replaced text: "street", "Street" and "STREET" are replaced by "st", "St" and "ST".
+	// Replacements of mixed case text are kept as given.
+	PreserveCase bool
+}
+
+// WordReplacer replaces whole words and phrases in a single pass. Words are delimited by the
+// Unicode word boundaries of UAX #29, so "st" is replaced in "st.", "(st)" and "st, louis"
+// but not in "stay". Keys of several words, such as "saint louis", match any run of space
+// between their words. When keys overlap the longest one is replaced. A WordReplacer is safe
+// for concurrent use.
+type WordReplacer struct {
+	name string
+	opts WordReplacerOptions
+	root wordNode
+}
+
+type wordNode struct {
+	children    map[string]*wordNode
+	terminal    bool
+	replacement string
+}
+
+// NewWordReplacer returns a replacer of the keys of given map into their values. Empty keys
+// are ignored. When case insensitive keys collide, the first one in sorted order is kept.
+func NewWordReplacer(replacements map[string]string, opts WordReplacerOptions) *WordReplacer {
+	keys := make([]string, 0, len(replacements))
+	for k := range replacements {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	r := &WordReplacer{opts: opts}
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+replacements[k])
+		if k == "" {
+			continue
+		}
+		node := &r.root
+		for _, word := range splitWords(k) {
+			word = r.fold(word)
+			child := node.children[word]
+			if child == nil {
+				if node.children == nil {
+					node.children = make(map[string]*wordNode)
+				}
+				child = &wordNode{}
+				node.children[word] = child
+			}
+			node = child
+		}
+		if !node.terminal {
+			node.terminal, node.replacement = true, replacements[k]
+		}
+	}
+	r.name = stepName("WordReplacer", pairs...)
+	return r
+}
+
+func (r *WordReplacer) String() string { return r.name }
+
+// Replace returns given string with the keys of the replacer replaced, and the number of
+// replacements made.
+func (r *WordReplacer) Replace(s string) (string, int) {
+	words := splitWords(s)
+	var b strings.Builder
+	count := 0
+	for i := 0; i < len(words); {
+		end, replacement := r.longestMatch(words[i:])
+		if end == 0 {
+			b.WriteString(words[i])
+			i++
+			continue
+		}
+		if r.opts.PreserveCase {
+			replacement = matchCase(strings.Join(words[i:i+end], ""), replacement)
+		}
+		b.WriteString(replacement)
+		count++
+		i += end
+	}
+	return b.String(), count
+}
+
+// longestMatch returns the number of words of the longest key starting words and its
+// replacement, or 0 when no key matches.
+func (r *WordReplacer) longestMatch(words []string) (int, string) {
+	end, replacement := 0, ""
+	node := &r.root
+	for i, word := range words {
+		node = node.children[r.fold(word)]
+		if node == nil {
+			break
+		}
+		if node.terminal {
+			end, replacement = i+1, node.replacement
+		}
+	}
+	return end, replacement
+}
+
+// fold returns the form of given word the keys are matched with.
+func (r *WordReplacer) fold(word string) string {
+	if strings.TrimSpace(word) == "" {
+		return " "
+	}
+	if r.opts.CaseInsensitive || r.opts.PreserveCase {
+		return strings.ToLower(word)
+	}
+	return word
+}
+
+// splitWords splits s at its word boundaries. Runs of space are returned as single words.
+func splitWords(s string) []string {
+	var words []string
+	state := -1
+	for s != "" {
+		var word string
+		word, s, state = uniseg.FirstWordInString(s, state)
+		words = append(words, word)
+	}
+	return words
+}
+
+// matchCase applies the letter case of text to replacement.
+func matchCase(text, replacement string) string {
+	upper, lower := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	switch {
+	case upper == 0 && lower > 0:
+		return strings.ToLower(replacement)
+	case upper > 1 && lower == 0:
+		return strings.ToUpper(replacement)
+	case upper > 0 && isTitleCase(text):
+		return titleWords(replacement)
+	}
+	return replacement
+}
+
+// isTitleCase checks if every word of s starts with an upper case letter followed by lower
+// case letters.
+func isTitleCase(s string) bool {
+	for _, word := range splitWords(s) {
+		for i, r := range []rune(word) {
+			if i == 0 && unicode.IsLower(r) || i > 0 && unicode.IsUpper(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// titleWords upper cases the first letter of every word of s and lower cases the others.
+func titleWords(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		rs := []rune(strings.ToLower(word))
+		if len(rs) > 0 {
+			rs[0] = unicode.ToTitle(rs[0])
+		}
+		words[i] = string(rs)
+	}
+	return strings.Join(words, "")
+}
+
+// ReplaceWords appends a step replacing words with given replacer.
+func (p *Pipeline) ReplaceWords(r *WordReplacer) *Pipeline {
+	return p.Then(r.String(), func(s string) string {
+		s, _ = r.Replace(s)
+		return s
+	})
+}
@@ -0,0 +1,75 @@
+package agstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordReplacer(t *testing.T) {
+	replacements := map[string]string{
+		"st":          "saint",
+		"saint louis": "St. Louis",
+		"foo":         "bar",
+		"c++":         "cpp",
+		"":            "empty",
+	}
+	testCases := []struct {
+		input    string
+		expected string
+		count    int
+	}{
+		{"port st jose", "port saint jose", 1},
+		{"st st st", "saint saint saint", 3},
+		{"foo, (foo) \"foo\" foo.", "bar, (bar) \"bar\" bar.", 4},
+		{"foobar stay food", "foobar stay food", 0},
+		{"saint  louis st", "St. Louis saint", 2},
+		{"saint lou", "saint lou", 0},
+		{"c++ and c", "cpp and c", 1},
+		{"Foo ST", "Foo ST", 0},
+		{"çay st ğ", "çay saint ğ", 1},
+		{"", "", 0},
+	}
+
+	r := NewWordReplacer(replacements, WordReplacerOptions{})
+	for _, testCase := range testCases {
+		actual, count := r.Replace(testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+		require.Equal(t, testCase.count, count, "for %q", testCase.input)
+	}
+}
+
+func TestWordReplacerCase(t *testing.T) {
+	replacements := map[string]string{"street": "St", "saint louis": "st. louis", "avenue": "Ave"}
+
+	insensitive := NewWordReplacer(replacements, WordReplacerOptions{CaseInsensitive: true})
+	actual, count := insensitive.Replace("Main STREET, Saint Louis")
+	require.Equal(t, "Main St, st. louis", actual)
+	require.Equal(t, 2, count)
+
+	preserving := NewWordReplacer(replacements, WordReplacerOptions{PreserveCase: true})
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"street", "st"},
+		{"Street", "St"},
+		{"STREET", "ST"},
+		{"StReEt", "St"},
+		{"Saint Louis", "St. Louis"},
+		{"SAINT LOUIS", "ST. LOUIS"},
+		{"saint Louis", "st. louis"},
+		{"5th AVENUE", "5th AVE"},
+	}
+	for _, testCase := range testCases {
+		actual, _ := preserving.Replace(testCase.input)
+		require.Equal(t, testCase.expected, actual, "for %q", testCase.input)
+	}
+}
+
+func TestWordReplacerPipeline(t *testing.T) {
+	r := NewWordReplacer(map[string]string{"rd": "road", "st": "street"}, WordReplacerOptions{})
+	p := NewPipeline().ReplaceWords(r)
+	require.Equal(t, "main road, high street", p.Apply("main rd, high st"))
+	require.Equal(t, `WordReplacer("rd=road", "st=street")`, p.String())
+}